@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+func TestSetAbsent(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c, err := NewLRUCache(10, 0, &noopMetrics{}, WithClock(clk), WithAbsentTTL(time.Second),
+		WithEvictionPolicy(EvictionPolicySIEVE))
+	require.NoError(t, err)
+
+	require.NoError(t, c.SetAbsent("b1", "k1", Options{}))
+	_, err = c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrAbsent)
+	assert.False(t, IsMiss(err))
+
+	// Default absent TTL
+	clk.Advance(2 * time.Second)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrExpired)
+
+	// Replaced by value
+	require.NoError(t, c.SetAbsent("b1", "k1", Options{TTL: time.Minute}))
+	clk.Advance(2 * time.Second)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrAbsent)
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{}))
+	v, err := c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), v)
+}
+
+func TestSetAbsentDefaultTTL(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c := newTestCache(t, 10, 0, WithClock(clk))
+
+	// Known absent key expires even if there is no TTL
+	require.NoError(t, c.SetAbsent("b1", "k1", Options{}))
+	ttl, err := c.TTL("b1", "k1")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAbsentTTL, ttl)
+	clk.Advance(DefaultAbsentTTL + time.Second)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrExpired)
+}
+
+func TestGetOrLoadAbsent(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &loadMetrics{}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk), WithAbsentTTL(time.Second))
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		calls.Add(1)
+		return nil, fmt.Errorf("select %s from %s: %w", key, bucket, ErrNotFound)
+	}
+	for range 3 {
+		_, err = c.GetOrLoad(context.Background(), "b1", "k1", Options{TTL: time.Minute}, loader)
+		assert.ErrorIs(t, err, ErrAbsent)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 0, metrics.loadErrors, "not found is not a load error")
+
+	// Load again after absent TTL
+	clk.Advance(2 * time.Second)
+	_, err = c.GetOrLoad(context.Background(), "b1", "k1", Options{TTL: time.Minute}, loader)
+	assert.ErrorIs(t, err, ErrAbsent)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Not cached without absent TTL
+	c2, err := NewLRUCache(10, 0, metrics)
+	require.NoError(t, err)
+	_, err = c2.GetOrLoad(context.Background(), "b1", "k1", Options{}, loader)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = c2.Get("b1", "k1", Options{})
+	assert.True(t, IsMiss(err))
+	assert.Equal(t, 1, metrics.loadErrors)
+}
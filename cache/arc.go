@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"container/list"
+)
+
+var _ evictor = &arcEvictor{}
+
+// Segments of [arcEvictor], stored in [cacheEntry] segment.
+const (
+	segmentARCRecent uint8 = iota
+	segmentARCFrequent
+)
+
+// arcEvictor implements [EvictionPolicyARC] using Adaptive Replacement Cache from
+// https://www.usenix.org/legacy/events/fast03/tech/full_papers/megiddo/megiddo.pdf
+// It is similar to ARCCache in https://github.com/hashicorp/golang-lru
+//
+// Entries seen once are in t1 and entries seen at least twice are in t2.
+// Keys evicted from them are remembered in ghost lists b1 and b2, a miss that hits
+// a ghost list adapts the target size p of t1 towards recency or frequency.
+type arcEvictor struct {
+	capacity int
+	// p is the target size of t1.
+	p int
+
+	t1 *list.List
+	t2 *list.List
+	b1 *ghostList
+	b2 *ghostList
+}
+
+func newARCEvictor(capacity int) *arcEvictor {
+	return &arcEvictor{
+		capacity: capacity,
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       newGhostList(),
+		b2:       newGhostList(),
+	}
+}
+
+func (a *arcEvictor) add(e *cacheEntry) {
+	key := ghostKey(e)
+	switch {
+	case a.b1.remove(key):
+		// Recency list was too small
+		delta := max(1, a.b2.len()/max(a.b1.len(), 1))
+		a.p = min(a.capacity, a.p+delta)
+		a.push(e, segmentARCFrequent)
+	case a.b2.remove(key):
+		// Frequency list was too small
+		delta := max(1, a.b1.len()/max(a.b2.len(), 1))
+		a.p = max(0, a.p-delta)
+		a.push(e, segmentARCFrequent)
+	default:
+		a.push(e, segmentARCRecent)
+	}
+
+	// Bound the ghost lists, |t1| + |b1| <= c and total <= 2c
+	if a.t1.Len()+a.b1.len() > a.capacity {
+		a.b1.removeOldest()
+	}
+	if a.t1.Len()+a.t2.Len()+a.b1.len()+a.b2.len() > 2*a.capacity {
+		a.b2.removeOldest()
+	}
+}
+
+func (a *arcEvictor) access(e *cacheEntry) {
+	if e.segment == segmentARCRecent {
+		a.t1.Remove(e.elem)
+		a.push(e, segmentARCFrequent)
+		return
+	}
+	a.t2.MoveToFront(e.elem)
+}
+
+func (a *arcEvictor) remove(e *cacheEntry) {
+	a.list(e.segment).Remove(e.elem)
+	e.elem = nil
+}
+
+// victim evicts from t1 when it is larger than its target size, the key is
+// remembered in the ghost list of its segment.
+func (a *arcEvictor) victim() *cacheEntry {
+	var elem *list.Element
+	if a.t1.Len() > 0 && (a.t1.Len() > a.p || a.t2.Len() == 0) {
+		elem = a.t1.Back()
+	} else {
+		elem = a.t2.Back()
+	}
+	if elem == nil {
+		return nil
+	}
+
+	e := elem.Value.(*cacheEntry)
+	if e.segment == segmentARCRecent {
+		a.b1.push(ghostKey(e))
+	} else {
+		a.b2.push(ghostKey(e))
+	}
+	return e
+}
+
+// victimIn does not remember the key in ghost lists because it is evicted
+// due to the bucket limit instead of the cache being too small.
+func (a *arcEvictor) victimIn(bucket string) *cacheEntry {
+	if e := lastIn(a.t1, bucket); e != nil {
+		return e
+	}
+	return lastIn(a.t2, bucket)
+}
+
+func (a *arcEvictor) len() int {
+	return a.t1.Len() + a.t2.Len()
+}
+
+func (a *arcEvictor) list(segment uint8) *list.List {
+	if segment == segmentARCRecent {
+		return a.t1
+	}
+	return a.t2
+}
+
+func (a *arcEvictor) push(e *cacheEntry, segment uint8) {
+	e.segment = segment
+	e.elem = a.list(segment).PushFront(e)
+}
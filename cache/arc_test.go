@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestARCEvictor(t *testing.T) {
+	a := newARCEvictor(2)
+	e1 := &cacheEntry{bucket: "b1", key: "k1"}
+	e2 := &cacheEntry{bucket: "b1", key: "k2"}
+	a.add(e1)
+	a.add(e2)
+	assert.Equal(t, 2, a.t1.Len())
+
+	// Hit moves to frequent
+	a.access(e1)
+	assert.Equal(t, segmentARCFrequent, e1.segment)
+
+	// Evict from t1 and remember it in b1
+	v := a.victim()
+	assert.Equal(t, e2, v)
+	a.remove(v)
+	assert.Equal(t, 1, a.b1.len())
+
+	// Ghost hit adapts p towards recency and goes to t2 directly
+	e2 = &cacheEntry{bucket: "b1", key: "k2"}
+	a.add(e2)
+	assert.Equal(t, 1, a.p)
+	assert.Equal(t, segmentARCFrequent, e2.segment)
+	assert.Equal(t, 0, a.b1.len())
+	assert.Equal(t, 2, a.len())
+}
@@ -0,0 +1,141 @@
+package cache
+
+// BatchKey is a key in [Cache.MGet] and [Cache.MDelete], keys can be in different buckets.
+type BatchKey struct {
+	Bucket string
+	Key    string
+}
+
+// BatchItem is a value to set in [Cache.MSet], each item has its own options.
+type BatchItem struct {
+	Bucket string
+	Key    string
+	Value  []byte
+	Opts   Options
+}
+
+// BatchResult is the result of a single key in [Cache.MGet], Err is the same as [Cache.GetWithVersion].
+type BatchResult struct {
+	Value   []byte
+	Version uint64
+	Err     error
+}
+
+// MGet gets all the keys under the lock once, results are in the same order as keys.
+func (c *LRUCache) MGet(keys []BatchKey, opts Options) []BatchResult {
+	results := make([]BatchResult, len(keys))
+	if len(keys) == 0 {
+		return results
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Same as Get, but only evict once for the batch.
+	if !c.sharedGet && c.evictor.len() >= c.capacity {
+		c.evict()
+	}
+	for i, k := range keys {
+		r := &results[i]
+		r.Value, r.Version, r.Err = c.get(k.Bucket, k.Key)
+	}
+	return results
+}
+
+// MSet sets all the items under the lock once, errors are in the same order as items.
+// Failed item does not stop the rest, nil means the item is set.
+func (c *LRUCache) MSet(items []BatchItem) []error {
+	errs := make([]error, len(items))
+	if len(items) == 0 {
+		return errs
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, item := range items {
+		c.metrics.AddSet()
+		_, errs[i] = c.set(item.Bucket, item.Key, item.Value, item.Opts)
+	}
+	c.reportSize()
+	return errs
+}
+
+// MDelete deletes all the keys under the lock once, errors are in the same order as keys.
+func (c *LRUCache) MDelete(keys []BatchKey) []error {
+	errs := make([]error, len(keys))
+	if len(keys) == 0 {
+		return errs
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, k := range keys {
+		c.metrics.AddDelete()
+		errs[i] = c.delete(k.Bucket, k.Key)
+	}
+	c.reportSize()
+	return errs
+}
+
+// MGet groups keys by shard, each shard is locked once.
+// NOTE: Shards are locked one by one, the batch is not a snapshot of the entire cache.
+func (c *ShardedCache) MGet(keys []BatchKey, opts Options) []BatchResult {
+	results := make([]BatchResult, len(keys))
+	for shard, indexes := range c.groupKeys(len(keys), func(i int) (string, string) {
+		return keys[i].Bucket, keys[i].Key
+	}) {
+		shardKeys := make([]BatchKey, len(indexes))
+		for j, i := range indexes {
+			shardKeys[j] = keys[i]
+		}
+		for j, r := range shard.MGet(shardKeys, opts) {
+			results[indexes[j]] = r
+		}
+	}
+	return results
+}
+
+// MSet groups items by shard, each shard reports its size after its part of the batch.
+func (c *ShardedCache) MSet(items []BatchItem) []error {
+	errs := make([]error, len(items))
+	for shard, indexes := range c.groupKeys(len(items), func(i int) (string, string) {
+		return items[i].Bucket, items[i].Key
+	}) {
+		shardItems := make([]BatchItem, len(indexes))
+		for j, i := range indexes {
+			shardItems[j] = items[i]
+		}
+		for j, err := range shard.MSet(shardItems) {
+			errs[indexes[j]] = err
+		}
+	}
+	return errs
+}
+
+func (c *ShardedCache) MDelete(keys []BatchKey) []error {
+	errs := make([]error, len(keys))
+	for shard, indexes := range c.groupKeys(len(keys), func(i int) (string, string) {
+		return keys[i].Bucket, keys[i].Key
+	}) {
+		shardKeys := make([]BatchKey, len(indexes))
+		for j, i := range indexes {
+			shardKeys[j] = keys[i]
+		}
+		for j, err := range shard.MDelete(shardKeys) {
+			errs[indexes[j]] = err
+		}
+	}
+	return errs
+}
+
+// groupKeys returns indexes of n keys grouped by their shard.
+func (c *ShardedCache) groupKeys(n int, key func(i int) (string, string)) map[*LRUCache][]int {
+	groups := make(map[*LRUCache][]int)
+	for i := range n {
+		shard := c.shard(key(i))
+		groups[shard] = append(groups[shard], i)
+	}
+	return groups
+}
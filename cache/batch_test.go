@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	lru := newTestCache(t, 100, 0, WithMaxBytes(100))
+	sharded, err := NewShardedCache(4, 100, 0, &noopMetrics{}, WithMaxBytes(400))
+	require.NoError(t, err)
+
+	for _, c := range []Cache{lru, sharded} {
+		t.Run(fmt.Sprintf("%T", c), func(t *testing.T) {
+			errs := c.MSet([]BatchItem{
+				{Bucket: "b1", Key: "k1", Value: []byte("v1")},
+				{Bucket: "b2", Key: "k2", Value: []byte("v2"), Opts: Options{Tags: []string{"t1"}}},
+				{Bucket: "b1", Key: "big", Value: make([]byte, 200)},
+			})
+			require.Len(t, errs, 3)
+			assert.NoError(t, errs[0])
+			assert.NoError(t, errs[1])
+			assert.ErrorIs(t, errs[2], ErrTooLarge)
+
+			keys := []BatchKey{{"b1", "k1"}, {"b2", "k2"}, {"b1", "missing"}, {"b3", "k1"}}
+			results := c.MGet(keys, Options{})
+			require.Len(t, results, 4)
+			assert.Equal(t, []byte("v1"), results[0].Value)
+			assert.NoError(t, results[0].Err)
+			assert.NotZero(t, results[0].Version)
+			assert.Equal(t, []byte("v2"), results[1].Value)
+			assert.True(t, IsMiss(results[2].Err))
+			assert.ErrorIs(t, results[3].Err, ErrBucketNotFound)
+			var keyErr *KeyError
+			require.ErrorAs(t, results[2].Err, &keyErr)
+			assert.Equal(t, "missing", keyErr.Key)
+
+			errs = c.MDelete(keys)
+			require.Len(t, errs, 4)
+			assert.NoError(t, errs[0])
+			assert.NoError(t, errs[1])
+			assert.True(t, IsMiss(errs[2]))
+			assert.True(t, IsMiss(errs[3]))
+			assert.Empty(t, c.ListBuckets())
+			assert.Equal(t, 0, c.InvalidateTag("t1"))
+
+			assert.Empty(t, c.MGet(nil, Options{}))
+		})
+	}
+}
+
+func TestBatchReportSize(t *testing.T) {
+	items := []BatchItem{{Bucket: "b1", Key: "k1", Value: []byte("v")}, {Bucket: "b1", Key: "k2", Value: []byte("v")}}
+	keys := []BatchKey{{"b1", "k1"}}
+
+	metrics := &sizeMetrics{}
+	lru, err := NewLRUCache(10, 0, metrics)
+	require.NoError(t, err)
+	lru.MSet(items)
+	assert.Equal(t, 2, metrics.size)
+	lru.MDelete(keys)
+	assert.Equal(t, 1, metrics.size)
+
+	metrics = &sizeMetrics{}
+	sharded, err := NewShardedCache(4, 100, 0, metrics)
+	require.NoError(t, err)
+	sharded.MSet(items)
+	assert.Equal(t, 2, metrics.size)
+	sharded.MDelete(keys)
+	assert.Equal(t, 1, metrics.size)
+}
+
+// keysInShards returns one key for each of the first n shards in shard index order.
+func keysInShards(t *testing.T, c *ShardedCache, bucket string, n int) []string {
+	t.Helper()
+	require.LessOrEqual(t, n, len(c.shards))
+	keys := make([]string, n)
+	found := 0
+	for i := 0; found < n && i < 10000; i++ {
+		key := fmt.Sprintf("k%d", i)
+		shard := c.shard(bucket, key)
+		for j := 0; j < n; j++ {
+			if c.shards[j] == shard && keys[j] == "" {
+				keys[j] = key
+				found++
+			}
+		}
+	}
+	require.Equal(t, n, found)
+	return keys
+}
+
+func TestShardedBatch(t *testing.T) {
+	c, err := NewShardedCache(4, 100, 0, &noopMetrics{}, WithMaxBytes(400))
+	require.NoError(t, err)
+	keys := keysInShards(t, c, "b1", 4)
+
+	// Item on the last shard is too large, items on other shards are still set
+	items := make([]BatchItem, len(keys))
+	for i, key := range keys {
+		items[i] = BatchItem{Bucket: "b1", Key: key, Value: []byte(key)}
+	}
+	items[3].Value = make([]byte, 200)
+	errs := c.MSet(items)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, errs[i])
+		_, ok := c.shards[i].buckets["b1"][keys[i]]
+		assert.True(t, ok, "key is set in its own shard")
+	}
+	assert.ErrorIs(t, errs[3], ErrTooLarge)
+
+	// Results are in request order across shards, including duplicated keys
+	batchKeys := []BatchKey{{"b1", keys[2]}, {"b1", keys[0]}, {"b1", keys[3]}, {"b1", keys[2]}}
+	results := c.MGet(batchKeys, Options{})
+	assert.Equal(t, []byte(keys[2]), results[0].Value)
+	assert.Equal(t, []byte(keys[0]), results[1].Value)
+	assert.True(t, IsMiss(results[2].Err))
+	assert.Equal(t, []byte(keys[2]), results[3].Value)
+
+	errs = c.MDelete(batchKeys)
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.True(t, IsMiss(errs[2]))
+	assert.True(t, IsMiss(errs[3]), "duplicated key is already deleted")
+	assert.Equal(t, []string{"b1"}, c.ListBuckets())
+	assert.Equal(t, 1, c.shards[1].evictor.len())
+}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// BucketConfig limits a single bucket so a noisy bucket only evicts its own keys.
+// Limits are in addition to the limits of the entire cache, 0 means no limit.
+type BucketConfig struct {
+	MaxEntries int   `json:"max_entries"`
+	MaxBytes   int64 `json:"max_bytes"`
+}
+
+func (b BucketConfig) validate() error {
+	if b.MaxEntries < 0 {
+		return fmt.Errorf("%w: max entries cannot be negative: %d", ErrInvalidConfig, b.MaxEntries)
+	}
+	if b.MaxBytes < 0 {
+		return fmt.Errorf("%w: max bytes cannot be negative: %d", ErrInvalidConfig, b.MaxBytes)
+	}
+	return nil
+}
+
+// ConfigureBucket sets the limits of a bucket, keys are evicted from the bucket
+// if it is already over the new limits. The config is kept even if the bucket is empty.
+func (c *LRUCache) ConfigureBucket(bucket string, cfg BucketConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.bucketConfigs[bucket] = cfg
+	c.makeBucketRoom(bucket, 0, 0)
+	return nil
+}
+
+// BucketConfig returns the limits of a bucket, it is the default from
+// [WithDefaultBucketConfig] if the bucket is not configured.
+func (c *LRUCache) BucketConfig(bucket string) BucketConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.bucketConfig(bucket)
+}
+
+// NOTE: caller must hold the lock.
+func (c *LRUCache) bucketConfig(bucket string) BucketConfig {
+	if cfg, ok := c.bucketConfigs[bucket]; ok {
+		return cfg
+	}
+	return c.defaultBucketConfig
+}
+
+// makeBucketRoom evicts keys in the bucket until there is room for
+// number of new entries with size bytes.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) makeBucketRoom(bucket string, entries int, size int64) {
+	cfg := c.bucketConfig(bucket)
+	if cfg.MaxEntries == 0 && cfg.MaxBytes == 0 {
+		return
+	}
+
+	for {
+		n := len(c.buckets[bucket])
+		if n == 0 {
+			return
+		}
+		if (cfg.MaxEntries == 0 || n+entries <= cfg.MaxEntries) &&
+			(cfg.MaxBytes == 0 || c.bucketBytes[bucket]+size <= cfg.MaxBytes) {
+			return
+		}
+		e := c.evictor.victimIn(bucket)
+		if e == nil {
+			return
+		}
+		c.del(e)
+		c.metrics.AddEvict()
+	}
+}
+
+// BucketStats is a snapshot of a bucket, expired keys are included until they are removed.
+type BucketStats struct {
+	Entries int   `json:"entries"`
+	Bytes   int64 `json:"bytes"`
+	// Oldest and Newest are the earliest and latest time values in the bucket were set.
+	Oldest time.Time `json:"oldest"`
+	Newest time.Time `json:"newest"`
+}
+
+// merge is used by [ShardedCache] to combine stats of the same bucket in different shards.
+func (s BucketStats) merge(other BucketStats) BucketStats {
+	if s.Entries == 0 {
+		return other
+	}
+	if other.Entries == 0 {
+		return s
+	}
+	s.Entries += other.Entries
+	s.Bytes += other.Bytes
+	if other.Oldest.Before(s.Oldest) {
+		s.Oldest = other.Oldest
+	}
+	if other.Newest.After(s.Newest) {
+		s.Newest = other.Newest
+	}
+	return s
+}
+
+// ListBuckets returns names of non empty buckets in sorted order.
+func (c *LRUCache) ListBuckets() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	buckets := make([]string, 0, len(c.buckets))
+	for bucket := range c.buckets {
+		buckets = append(buckets, bucket)
+	}
+	slices.Sort(buckets)
+	return buckets
+}
+
+// BucketStats walks all the keys in the bucket to find the oldest and newest.
+func (c *LRUCache) BucketStats(bucket string) (BucketStats, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	b, ok := c.buckets[bucket]
+	if !ok {
+		return BucketStats{}, fmt.Errorf("bucket %s: %w", bucket, ErrBucketNotFound)
+	}
+	stats := BucketStats{Entries: len(b), Bytes: c.bucketBytes[bucket]}
+	for _, e := range b {
+		if stats.Oldest.IsZero() || e.modified.Before(stats.Oldest) {
+			stats.Oldest = e.modified
+		}
+		if e.modified.After(stats.Newest) {
+			stats.Newest = e.modified
+		}
+	}
+	return stats, nil
+}
+
+// FlushBucket deletes all the keys in the bucket and returns the number of deleted keys.
+// The config of the bucket is kept.
+func (c *LRUCache) FlushBucket(bucket string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.buckets[bucket]
+	if !ok {
+		return 0, fmt.Errorf("bucket %s: %w", bucket, ErrBucketNotFound)
+	}
+	n := len(b)
+	for _, e := range b {
+		c.del(e)
+		c.metrics.AddDelete()
+	}
+	c.reportSize()
+	return n, nil
+}
+
+// Flush deletes all the keys in all the buckets and returns the number of deleted keys.
+// Bucket configs are kept.
+func (c *LRUCache) Flush() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, b := range c.buckets {
+		for _, e := range b {
+			c.del(e)
+			c.metrics.AddDelete()
+			n++
+		}
+	}
+	c.reportSize()
+	return n
+}
@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+func TestBucketMaxEntries(t *testing.T) {
+	for _, policy := range []EvictionPolicy{EvictionPolicyLRU, EvictionPolicyMRU, EvictionPolicyLFU, EvictionPolicyTinyLFU,
+		EvictionPolicyARC, EvictionPolicy2Q, EvictionPolicySIEVE, EvictionPolicyCLOCK} {
+		t.Run(policy.String(), func(t *testing.T) {
+			c := newTestCache(t, 100, 0, WithEvictionPolicy(policy))
+			require.NoError(t, c.ConfigureBucket("noisy", BucketConfig{MaxEntries: 2}))
+			c.Set("quiet", "k1", []byte("v1"), Options{})
+			for i := 0; i < 10; i++ {
+				require.NoError(t, c.Set("noisy", fmt.Sprintf("k%d", i), []byte("v"), Options{}))
+			}
+			assert.Len(t, c.buckets["noisy"], 2)
+			assert.Len(t, c.buckets["quiet"], 1)
+			assert.Equal(t, 3, c.evictor.len())
+		})
+	}
+}
+
+func TestBucketMaxBytes(t *testing.T) {
+	c := newTestCache(t, 100, 0, WithDefaultBucketConfig(BucketConfig{MaxBytes: 20}))
+	assert.Equal(t, BucketConfig{MaxBytes: 20}, c.BucketConfig("b1"))
+
+	// 2 + 2 + 10
+	require.NoError(t, c.Set("b1", "k1", []byte("0123456789"), Options{}))
+	require.NoError(t, c.Set("b2", "k1", []byte("0123456789"), Options{}))
+	// Evicts b1/k1 only
+	require.NoError(t, c.Set("b1", "k2", []byte("0123456789"), Options{}))
+	assert.Len(t, c.buckets["b1"], 1)
+	assert.Len(t, c.buckets["b2"], 1)
+	assert.Equal(t, int64(14), c.bucketBytes["b1"])
+
+	err := c.Set("b1", "k3", []byte("0123456789012345678"), Options{})
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	// Configured bucket does not use the default
+	require.NoError(t, c.ConfigureBucket("b3", BucketConfig{}))
+	require.NoError(t, c.Set("b3", "k1", []byte("0123456789012345678"), Options{}))
+}
+
+func TestConfigureBucket(t *testing.T) {
+	c := newTestCache(t, 100, 0)
+	for i := 0; i < 5; i++ {
+		c.Set("b1", fmt.Sprintf("k%d", i), []byte("v"), Options{})
+	}
+	// Evicts existing keys when the limit is lowered
+	require.NoError(t, c.ConfigureBucket("b1", BucketConfig{MaxEntries: 3}))
+	assert.Len(t, c.buckets["b1"], 3)
+	_, err := c.Get("b1", "k0", Options{})
+	assert.Error(t, err)
+
+	err = c.ConfigureBucket("b1", BucketConfig{MaxEntries: -1})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = NewLRUCache(10, 0, &noopMetrics{}, WithDefaultBucketConfig(BucketConfig{MaxBytes: -1}))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	// Config is kept after bucket is removed
+	for i := 0; i < 5; i++ {
+		c.Delete("b1", fmt.Sprintf("k%d", i))
+	}
+	assert.Equal(t, BucketConfig{MaxEntries: 3}, c.BucketConfig("b1"))
+}
+
+func TestShardedBucketConfig(t *testing.T) {
+	c, err := NewShardedCache(4, 100, 0, &noopMetrics{})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	require.NoError(t, c.ConfigureBucket("b1", BucketConfig{MaxEntries: 2, MaxBytes: 100}))
+	assert.Equal(t, BucketConfig{MaxEntries: 2, MaxBytes: 100}, c.BucketConfig("b1"))
+	assert.Equal(t, BucketConfig{MaxEntries: 1, MaxBytes: 25}, c.shards[3].BucketConfig("b1"))
+}
+
+func TestBucketStats(t *testing.T) {
+	start := time.Now()
+	clk := clocktest.NewFake(start)
+	c := newTestCache(t, 100, 0, WithClock(clk))
+	assert.Empty(t, c.ListBuckets())
+	_, err := c.BucketStats("b1")
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+
+	require.NoError(t, c.Set("b2", "k1", []byte("v1"), Options{}))
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{}))
+	clk.Advance(time.Second)
+	require.NoError(t, c.Set("b1", "k2", []byte("v2"), Options{}))
+	assert.Equal(t, []string{"b1", "b2"}, c.ListBuckets())
+
+	stats, err := c.BucketStats("b1")
+	require.NoError(t, err)
+	assert.Equal(t, BucketStats{Entries: 2, Bytes: 12, Oldest: start, Newest: start.Add(time.Second)}, stats)
+
+	// Update changes the time
+	clk.Advance(time.Second)
+	_, err = c.Increment("b1", "k3", 1, 0, Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{}))
+	stats, err = c.BucketStats("b1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Entries)
+	assert.Equal(t, start.Add(time.Second), stats.Oldest)
+	assert.Equal(t, start.Add(2*time.Second), stats.Newest)
+}
+
+func TestFlush(t *testing.T) {
+	for _, policy := range []EvictionPolicy{EvictionPolicyLRU, EvictionPolicyLFU, EvictionPolicyTinyLFU,
+		EvictionPolicyARC, EvictionPolicy2Q, EvictionPolicySIEVE} {
+		t.Run(policy.String(), func(t *testing.T) {
+			metrics := &sizeMetrics{}
+			c, err := NewLRUCache(100, 0, metrics, WithEvictionPolicy(policy))
+			require.NoError(t, err)
+			require.NoError(t, c.ConfigureBucket("b1", BucketConfig{MaxEntries: 10}))
+			for i := 0; i < 5; i++ {
+				require.NoError(t, c.Set("b1", fmt.Sprintf("k%d", i), []byte("v"), Options{TTL: time.Hour}))
+				require.NoError(t, c.Set("b2", fmt.Sprintf("k%d", i), []byte("v"), Options{}))
+			}
+
+			n, err := c.FlushBucket("b1")
+			require.NoError(t, err)
+			assert.Equal(t, 5, n)
+			assert.Equal(t, []string{"b2"}, c.ListBuckets())
+			assert.Empty(t, c.expirations)
+			assert.Equal(t, 5, metrics.size)
+			assert.Equal(t, BucketConfig{MaxEntries: 10}, c.BucketConfig("b1"), "config is kept")
+			_, err = c.FlushBucket("b1")
+			assert.ErrorIs(t, err, ErrBucketNotFound)
+
+			require.NoError(t, c.Set("b1", "k1", []byte("v"), Options{}))
+			assert.Equal(t, 6, c.Flush())
+			assert.Empty(t, c.ListBuckets())
+			assert.Equal(t, 0, c.evictor.len())
+			assert.Equal(t, int64(0), c.bytes)
+
+			// Still works after flush
+			require.NoError(t, c.Set("b1", "k1", []byte("v"), Options{}))
+			_, err = c.Get("b1", "k1", Options{})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cache
+
+// CompareAndSet sets the value only if the current version of the key matches version,
+// version 0 means the key must not exist (known absent key counts as not exist).
+// It returns the new version, or [ErrVersionMismatch] if the key has been changed.
+func (c *LRUCache) CompareAndSet(bucket string, key string, value []byte, version uint64, opts Options) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current := c.currentVersion(bucket, key); current != version {
+		return 0, keyError(bucket, key, ErrVersionMismatch)
+	}
+
+	c.metrics.AddSet()
+	entry, err := c.set(bucket, key, value, opts)
+	if err != nil {
+		return 0, err
+	}
+	return entry.version, nil
+}
+
+// CompareAndDelete deletes the key only if its current version matches version.
+func (c *LRUCache) CompareAndDelete(bucket string, key string, version uint64) error {
+	c.metrics.AddDelete()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookup(bucket, key)
+	if err != nil {
+		c.addNotFound(err)
+		return err
+	}
+	if entry.absent || entry.version != version {
+		return keyError(bucket, key, ErrVersionMismatch)
+	}
+	c.del(entry)
+	return nil
+}
+
+// SetNX only sets the value if the key does not exist, known absent key counts as not exist.
+// It returns false if the key exists.
+func (c *LRUCache) SetNX(bucket string, key string, value []byte, opts Options) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.existing(bucket, key) != nil {
+		return false, nil
+	}
+	c.metrics.AddSet()
+	if _, err := c.set(bucket, key, value, opts); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetXX only sets the value if the key exists. It returns false if the key does not exist.
+func (c *LRUCache) SetXX(bucket string, key string, value []byte, opts Options) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.existing(bucket, key) == nil {
+		return false, nil
+	}
+	c.metrics.AddSet()
+	if _, err := c.set(bucket, key, value, opts); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetSet sets the value and returns the old value, it returns false if the key did not exist.
+// Old value is not returned if the new value can't be set.
+func (c *LRUCache) GetSet(bucket string, key string, value []byte, opts Options) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.metrics.AddSet()
+
+	var old []byte
+	entry := c.existing(bucket, key)
+	if entry != nil {
+		old = entry.value
+	}
+	if _, err := c.set(bucket, key, value, opts); err != nil {
+		return nil, false, err
+	}
+	return old, entry != nil, nil
+}
+
+// currentVersion returns 0 if the key does not exist, is expired or is known absent.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) currentVersion(bucket string, key string) uint64 {
+	if entry := c.existing(bucket, key); entry != nil {
+		return entry.version
+	}
+	return 0
+}
+
+// existing returns nil if the key does not exist, is expired or is known absent.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) existing(bucket string, key string) *cacheEntry {
+	entry, err := c.lookup(bucket, key)
+	if err != nil || entry.absent {
+		return nil
+	}
+	return entry
+}
@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+func TestCompareAndSet(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c := newTestCache(t, 10, 0, WithClock(clk))
+
+	// 0 means not exists
+	v1, err := c.CompareAndSet("b1", "k1", []byte("v1"), 0, Options{TTL: time.Second})
+	require.NoError(t, err)
+	_, err = c.CompareAndSet("b1", "k1", []byte("v1"), 0, Options{})
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+
+	value, version, err := c.GetWithVersion("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), value)
+	assert.Equal(t, v1, version)
+
+	// Version changes on every set
+	require.NoError(t, c.Set("b1", "k1", []byte("v2"), Options{}))
+	_, v2, err := c.GetWithVersion("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Greater(t, v2, v1)
+	_, err = c.CompareAndSet("b1", "k1", []byte("v3"), v1, Options{})
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	v3, err := c.CompareAndSet("b1", "k1", []byte("v3"), v2, Options{TTL: time.Second})
+	require.NoError(t, err)
+	assert.Greater(t, v3, v2)
+
+	// Expired and absent key are the same as not exists
+	clk.Advance(2 * time.Second)
+	_, err = c.CompareAndSet("b1", "k1", []byte("v4"), v3, Options{})
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	require.NoError(t, c.SetAbsent("b1", "k2", Options{}))
+	_, err = c.CompareAndSet("b1", "k2", []byte("v1"), 0, Options{})
+	require.NoError(t, err)
+}
+
+func TestCompareAndDelete(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+
+	err := c.CompareAndDelete("b1", "k1", 1)
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{}))
+	_, version, err := c.GetWithVersion("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, c.CompareAndDelete("b1", "k1", version+1), ErrVersionMismatch)
+	require.NoError(t, c.CompareAndDelete("b1", "k1", version))
+	_, err = c.Get("b1", "k1", Options{})
+	assert.True(t, IsMiss(err))
+}
+
+func TestCompareAndDeleteExpired(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &expireMetrics{}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{TTL: time.Second}))
+	clk.Advance(2 * time.Second)
+	// Counted as expire like Get, not as miss
+	assert.ErrorIs(t, c.CompareAndDelete("b1", "k1", 1), ErrExpired)
+	assert.Equal(t, 1, metrics.lazy)
+	assert.Equal(t, 0, metrics.notFound)
+	assert.ErrorIs(t, c.CompareAndDelete("b1", "k1", 1), ErrBucketNotFound)
+	assert.Equal(t, 1, metrics.notFound)
+}
+
+func TestCompareAndSetConcurrent(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+	require.NoError(t, c.Set("b1", "counter", []byte{0}, Options{}))
+
+	// Read modify write with retry never loses an update
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				for {
+					value, version, err := c.GetWithVersion("b1", "counter", Options{})
+					require.NoError(t, err)
+					if _, err := c.CompareAndSet("b1", "counter", []byte{value[0] + 1}, version, Options{}); err == nil {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	value, err := c.Get("b1", "counter", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, byte(800%256), value[0])
+}
+
+func TestSetNX(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c := newTestCache(t, 10, 0, WithClock(clk))
+
+	ok, err := c.SetNX("b1", "lock", []byte("owner1"), Options{TTL: time.Second})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = c.SetNX("b1", "lock", []byte("owner2"), Options{TTL: time.Second})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	v, err := c.Get("b1", "lock", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("owner1"), v)
+
+	// Lock is released after TTL
+	clk.Advance(2 * time.Second)
+	ok, err = c.SetNX("b1", "lock", []byte("owner2"), Options{TTL: time.Second})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Known absent key does not exist
+	require.NoError(t, c.SetAbsent("b1", "k1", Options{}))
+	ok, err = c.SetNX("b1", "k1", []byte("v1"), Options{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSetXX(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+
+	ok, err := c.SetXX("b1", "k1", []byte("v1"), Options{})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.True(t, IsMiss(err))
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{}))
+	ok, err = c.SetXX("b1", "k1", []byte("v2"), Options{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	v, err := c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), v)
+}
+
+func TestGetSet(t *testing.T) {
+	c := newTestCache(t, 10, 0, WithMaxBytes(10))
+
+	old, existed, err := c.GetSet("b1", "k1", []byte("v1"), Options{})
+	require.NoError(t, err)
+	assert.False(t, existed)
+	assert.Nil(t, old)
+
+	old, existed, err = c.GetSet("b1", "k1", []byte("v2"), Options{})
+	require.NoError(t, err)
+	assert.True(t, existed)
+	assert.Equal(t, []byte("v1"), old)
+
+	// Old value is kept if the new value is too large
+	_, _, err = c.GetSet("b1", "k1", []byte("too large value"), Options{})
+	assert.ErrorIs(t, err, ErrTooLarge)
+	v, err := c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), v)
+}
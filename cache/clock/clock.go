@@ -0,0 +1,44 @@
+// Package clock abstracts time so TTL in the cache can be tested without sleep.
+// Use [Real] in production and clocktest.Fake in tests.
+package clock
+
+import (
+	"time"
+)
+
+// Clock is the subset of time package used by the cache.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is [time.Ticker] as an interface.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+var _ Clock = Real{}
+
+// Real uses the time package.
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *realTicker) Stop() {
+	t.ticker.Stop()
+}
@@ -0,0 +1,90 @@
+// Package clocktest provides a fake [clock.Clock] for deterministic tests.
+package clocktest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/at15/tinycache/cache/clock"
+)
+
+var _ clock.Clock = &Fake{}
+
+// Fake only moves when [Fake.Advance] is called.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// NewFake creates a fake clock starting at now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTicker{
+		c:        make(chan time.Time),
+		done:     make(chan struct{}),
+		interval: d,
+		next:     f.now.Add(d),
+	}
+	f.tickers = append(f.tickers, t)
+	return t
+}
+
+// Advance moves the clock forward and fires tickers that are due, once per
+// elapsed interval. Unlike [time.Ticker], the channel is unbuffered and Advance
+// blocks until the tick is received or the ticker is stopped. So when a tick is
+// received, the receiver has finished handling the previous tick.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	now := f.now
+	tickers := make([]*fakeTicker, len(f.tickers))
+	copy(tickers, f.tickers)
+	f.mu.Unlock()
+
+	// Send without holding the lock because receiver may call Now.
+	for _, t := range tickers {
+		for !t.next.After(now) {
+			select {
+			case t.c <- t.next:
+			case <-t.done:
+			}
+			t.next = t.next.Add(t.interval)
+		}
+	}
+}
+
+type fakeTicker struct {
+	c        chan time.Time
+	done     chan struct{}
+	stopOnce sync.Once
+	interval time.Duration
+	// next is only accessed by Advance.
+	next time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.done)
+	})
+}
@@ -0,0 +1,38 @@
+package clocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	f.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), f.Now())
+
+	ticker := f.NewTicker(10 * time.Millisecond)
+	var ticks []time.Time
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for tick := range ticker.C() {
+			ticks = append(ticks, tick)
+			if len(ticks) == 2 {
+				return
+			}
+		}
+	}()
+	// Not due yet
+	f.Advance(5 * time.Millisecond)
+	// Fires twice
+	f.Advance(20 * time.Millisecond)
+	<-done
+	assert.Equal(t, []time.Time{start.Add(time.Second + 10*time.Millisecond), start.Add(time.Second + 20*time.Millisecond)}, ticks)
+
+	// Does not block after stop
+	ticker.Stop()
+	f.Advance(time.Second)
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"container/list"
+)
+
+var _ sharedAccessEvictor = &clockEvictor{}
+
+// clockEvictor implements [EvictionPolicyCLOCK], the second chance approximation of LRU.
+// Entries are in a circular list, a hit only sets the visited bit, so [LRUCache.Get]
+// can hold the read lock. On eviction the hand goes around the circle, clearing the visited
+// bit until it finds an entry that is not visited. New entries are inserted behind the hand
+// so they are checked last.
+type clockEvictor struct {
+	ring *list.List
+	// hand points to the next entry to check, nil means the front.
+	hand *list.Element
+}
+
+func newCLOCKEvictor() *clockEvictor {
+	return &clockEvictor{
+		ring: list.New(),
+	}
+}
+
+func (c *clockEvictor) add(e *cacheEntry) {
+	e.visited.Store(false)
+	if c.hand == nil {
+		e.elem = c.ring.PushBack(e)
+		return
+	}
+	e.elem = c.ring.InsertBefore(e, c.hand)
+}
+
+func (c *clockEvictor) access(e *cacheEntry) {
+	e.visited.Store(true)
+}
+
+func (c *clockEvictor) sharedAccess() {}
+
+func (c *clockEvictor) remove(e *cacheEntry) {
+	if c.hand == e.elem {
+		c.hand = e.elem.Next()
+	}
+	c.ring.Remove(e.elem)
+	e.elem = nil
+}
+
+func (c *clockEvictor) victim() *cacheEntry {
+	if c.ring.Len() == 0 {
+		return nil
+	}
+	elem := c.hand
+	if elem == nil {
+		elem = c.ring.Front()
+	}
+	for {
+		e := elem.Value.(*cacheEntry)
+		next := elem.Next()
+		if next == nil {
+			next = c.ring.Front()
+		}
+		if !e.visited.Load() {
+			c.hand = next
+			return e
+		}
+		e.visited.Store(false)
+		elem = next
+	}
+}
+
+// victimIn prefers the first entry that is not visited without moving the hand.
+func (c *clockEvictor) victimIn(bucket string) *cacheEntry {
+	for elem := c.ring.Front(); elem != nil; elem = elem.Next() {
+		if e := elem.Value.(*cacheEntry); e.bucket == bucket && !e.visited.Load() {
+			return e
+		}
+	}
+	return firstIn(c.ring, bucket)
+}
+
+func (c *clockEvictor) len() int {
+	return c.ring.Len()
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLOCKEvictor(t *testing.T) {
+	c := newCLOCKEvictor()
+	entries := make([]*cacheEntry, 3)
+	for i := range entries {
+		entries[i] = &cacheEntry{key: fmt.Sprintf("k%d", i)}
+		c.add(entries[i])
+	}
+	c.access(entries[0])
+
+	// k0 gets a second chance
+	v := c.victim()
+	assert.Equal(t, entries[1], v)
+	assert.False(t, entries[0].visited.Load())
+	c.remove(v)
+
+	// New entry is inserted behind the hand i.e. checked last
+	e3 := &cacheEntry{key: "k3"}
+	c.add(e3)
+	v = c.victim()
+	assert.Equal(t, entries[2], v)
+	c.remove(v)
+	v = c.victim()
+	assert.Equal(t, entries[0], v)
+	c.remove(v)
+	v = c.victim()
+	assert.Equal(t, e3, v)
+	c.remove(v)
+	assert.Nil(t, c.victim())
+}
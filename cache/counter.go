@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"strconv"
+)
+
+// Increment adds delta to the decimal integer value of the key and returns the result,
+// use negative delta to decrement. Missing, expired or known absent key starts from
+// initial and uses TTL in opts, existing key keeps its TTL.
+// It returns [ErrNotInteger] if the value is not a decimal integer
+// and [ErrOverflow] if the result does not fit in int64.
+func (c *LRUCache) Increment(bucket string, key string, delta int64, initial int64, opts Options) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n, err := c.increment(bucket, key, delta, initial, opts)
+	if err != nil {
+		return 0, err
+	}
+	c.metrics.AddSet()
+	return n, nil
+}
+
+// increment is Increment without lock.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) increment(bucket string, key string, delta int64, initial int64, opts Options) (int64, error) {
+	entry, err := c.lookup(bucket, key)
+	exists := err == nil && !entry.absent
+	var value []byte
+	if exists {
+		value = entry.value
+	}
+	n, err := incrementValue(value, exists, delta, initial)
+	if err != nil {
+		return 0, keyError(bucket, key, err)
+	}
+	value = strconv.AppendInt(nil, n, 10)
+	if !exists {
+		if _, err := c.set(bucket, key, value, opts); err != nil {
+			return 0, err
+		}
+		return n, nil
+	}
+
+	if err := c.checkSize(bucket, key, entrySize(bucket, key, value)); err != nil {
+		return 0, err
+	}
+	c.update(entry, value)
+	c.metrics.AddSetExists()
+	return n, nil
+}
+
+// incrementValue adds delta to the decimal integer value, it starts from initial if the value does not exist.
+func incrementValue(value []byte, exists bool, delta int64, initial int64) (int64, error) {
+	n := initial
+	if exists {
+		var err error
+		n, err = strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			return 0, ErrNotInteger
+		}
+	}
+	n, ok := addInt64(n, delta)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return n, nil
+}
+
+// addInt64 returns false if a + b overflows.
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
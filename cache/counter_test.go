@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+func TestIncrement(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c := newTestCache(t, 10, 0, WithClock(clk))
+
+	// Missing key starts from initial
+	n, err := c.Increment("b1", "k1", 1, 10, Options{TTL: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), n)
+	n, err = c.Increment("b1", "k1", -5, 10, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+	v, err := c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("6"), v)
+
+	// Existing key keeps its TTL
+	clk.Advance(2 * time.Second)
+	n, err = c.Increment("b1", "k1", 1, 0, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	require.NoError(t, c.Set("b1", "k2", []byte("v2"), Options{}))
+	_, err = c.Increment("b1", "k2", 1, 0, Options{})
+	assert.ErrorIs(t, err, ErrNotInteger)
+
+	require.NoError(t, c.Set("b1", "k3", []byte("9223372036854775807"), Options{}))
+	_, err = c.Increment("b1", "k3", 1, 0, Options{})
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = c.Increment("b1", "k4", -1, math.MinInt64, Options{})
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+type setMetrics struct {
+	noopMetrics
+	sets int
+}
+
+func (m *setMetrics) AddSet() {
+	m.sets++
+}
+
+func TestIncrementMetrics(t *testing.T) {
+	metrics := &setMetrics{}
+	c, err := NewLRUCache(10, 0, metrics)
+	require.NoError(t, err)
+
+	_, err = c.Increment("b1", "k1", 1, 0, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics.sets)
+
+	// Failed increment is not a set
+	_, err = c.Increment("b1", "k1", math.MaxInt64, 0, Options{})
+	assert.ErrorIs(t, err, ErrOverflow)
+	assert.Equal(t, 1, metrics.sets)
+}
+
+func TestIncrementConcurrent(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, err := c.Increment("b1", "counter", 2, 0, Options{})
+				assert.NoError(t, err)
+				_, err = c.Increment("b1", "counter", -1, 0, Options{})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	v, err := c.Get("b1", "counter", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("800"), v)
+}
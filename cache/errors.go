@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by [Cache] operations, use [errors.Is] to check them
+// because they are wrapped in [KeyError] most of the time.
+var (
+	// ErrNotFound is returned when the key does not exist in the bucket.
+	ErrNotFound = errors.New("key not found")
+	// ErrBucketNotFound is returned when the bucket does not exist.
+	// Empty bucket is removed so it is also a miss from caller's point of view.
+	ErrBucketNotFound = errors.New("bucket not found")
+	// ErrExpired is returned when the key exists but its TTL has passed.
+	ErrExpired = errors.New("key expired")
+	// ErrTooLarge is returned when the value can never fit into the cache.
+	ErrTooLarge = errors.New("value too large")
+	// ErrInvalidConfig is returned when configuration e.g. [BucketConfig] is invalid.
+	ErrInvalidConfig = errors.New("invalid config")
+	// ErrAbsent is returned when the key is cached as known absent by [Cache.SetAbsent]
+	// or [Cache.GetOrLoad]. It is not a miss, caller should not look it up again.
+	ErrAbsent = errors.New("key known absent")
+	// ErrVersionMismatch is returned by compare and set when the key has been changed
+	// since the version was read.
+	ErrVersionMismatch = errors.New("version mismatch")
+	// ErrNotInteger is returned by increment when the value is not a decimal integer.
+	ErrNotInteger = errors.New("value is not an integer")
+	// ErrOverflow is returned by increment when the result does not fit in int64.
+	ErrOverflow = errors.New("integer overflow")
+	// ErrInvalidCursor is returned by scan when the cursor is not returned by a previous scan.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidOp is returned by transaction when the operation type is unknown.
+	ErrInvalidOp = errors.New("invalid operation")
+)
+
+// KeyError records the bucket and key of a failed operation.
+// Use [errors.As] to get the bucket and key, and [errors.Is] to
+// check the underlying error e.g. [ErrNotFound].
+type KeyError struct {
+	Bucket string
+	Key    string
+	Err    error
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("bucket %s key %s: %v", e.Bucket, e.Key, e.Err)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// IsMiss returns true if the error means the key can't be found in the cache
+// i.e. one of [ErrNotFound], [ErrBucketNotFound] and [ErrExpired].
+// [ErrAbsent] is not a miss because the cache knows the key does not exist.
+func IsMiss(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrBucketNotFound) || errors.Is(err, ErrExpired)
+}
+
+func keyError(bucket, key string, err error) error {
+	return &KeyError{Bucket: bucket, Key: key, Err: err}
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+)
+
+// evictor tracks entries in [LRUCache] and decides which entry to evict
+// when capacity is reached. Each [EvictionPolicy] has its own evictor.
+// NOTE: evictor is not go routine safe, caller must hold the write lock.
+type evictor interface {
+	// add is called after a new entry is inserted.
+	add(e *cacheEntry)
+	// access is called when an existing entry is read or updated.
+	access(e *cacheEntry)
+	// remove is called when entry is deleted, expired or evicted.
+	remove(e *cacheEntry)
+	// victim returns the entry to evict, nil if there is no entry.
+	// It is only called when the cache is full and may reorganize the entries
+	// e.g. admitting a new entry. It does not remove the victim,
+	// caller calls remove after deleting it.
+	victim() *cacheEntry
+	// victimIn returns the entry to evict from a bucket when it reaches its [BucketConfig].
+	// It walks the entries in eviction order so it is O(n) in worst case.
+	victimIn(bucket string) *cacheEntry
+	// len returns number of entries tracked by the evictor.
+	len() int
+}
+
+// sharedAccessEvictor is implemented by evictors whose access is go routine safe
+// e.g. only setting a visited bit atomically, so [LRUCache.Get] can hold the read lock
+// on hit. Other methods still require the write lock.
+type sharedAccessEvictor interface {
+	evictor
+	sharedAccess()
+}
+
+func newEvictor(policy EvictionPolicy, capacity int, metrics MetricsHandler) (evictor, error) {
+	switch policy {
+	case EvictionPolicyNone, EvictionPolicyOldest, EvictionPolicyNewest, EvictionPolicyLRU, EvictionPolicyMRU:
+		return newListEvictor(policy), nil
+	case EvictionPolicyLFU:
+		return newLFUEvictor(), nil
+	case EvictionPolicyTinyLFU:
+		return newTinyLFUEvictor(capacity, metrics), nil
+	case EvictionPolicyARC:
+		return newARCEvictor(capacity), nil
+	case EvictionPolicy2Q:
+		return newTwoQEvictor(capacity), nil
+	case EvictionPolicySIEVE:
+		return newSIEVEEvictor(), nil
+	case EvictionPolicyCLOCK:
+		return newCLOCKEvictor(), nil
+	default:
+		return nil, fmt.Errorf("unsupported eviction policy %s", policy)
+	}
+}
+
+var _ evictor = &listEvictor{}
+
+// listEvictor uses a single linked list for [EvictionPolicyNone], [EvictionPolicyOldest],
+// [EvictionPolicyNewest], [EvictionPolicyLRU] and [EvictionPolicyMRU].
+type listEvictor struct {
+	policy EvictionPolicy
+	// order is by default the insertion order
+	// For [EvictionPolicyLRU] or [EvictionPolicyMRU], the order is also updated
+	// during Get and Set.
+	order *list.List
+}
+
+func newListEvictor(policy EvictionPolicy) *listEvictor {
+	return &listEvictor{
+		policy: policy,
+		order:  list.New(),
+	}
+}
+
+func (l *listEvictor) add(e *cacheEntry) {
+	e.elem = l.order.PushBack(e)
+}
+
+func (l *listEvictor) access(e *cacheEntry) {
+	if l.policy == EvictionPolicyLRU || l.policy == EvictionPolicyMRU {
+		l.order.MoveToBack(e.elem)
+	}
+}
+
+func (l *listEvictor) remove(e *cacheEntry) {
+	l.order.Remove(e.elem)
+	e.elem = nil
+}
+
+func (l *listEvictor) victim() *cacheEntry {
+	var elem *list.Element
+	switch l.policy {
+	case EvictionPolicyMRU, EvictionPolicyNewest:
+		elem = l.order.Back()
+	default:
+		// LRU, None, Oldest
+		elem = l.order.Front()
+	}
+	if elem == nil {
+		return nil
+	}
+	return elem.Value.(*cacheEntry)
+}
+
+func (l *listEvictor) victimIn(bucket string) *cacheEntry {
+	switch l.policy {
+	case EvictionPolicyMRU, EvictionPolicyNewest:
+		return lastIn(l.order, bucket)
+	default:
+		return firstIn(l.order, bucket)
+	}
+}
+
+func (l *listEvictor) len() int {
+	return l.order.Len()
+}
+
+// firstIn returns the entry closest to the front of the list that is in the bucket.
+func firstIn(l *list.List, bucket string) *cacheEntry {
+	for elem := l.Front(); elem != nil; elem = elem.Next() {
+		if e := elem.Value.(*cacheEntry); e.bucket == bucket {
+			return e
+		}
+	}
+	return nil
+}
+
+// lastIn returns the entry closest to the back of the list that is in the bucket.
+func lastIn(l *list.List, bucket string) *cacheEntry {
+	for elem := l.Back(); elem != nil; elem = elem.Prev() {
+		if e := elem.Value.(*cacheEntry); e.bucket == bucket {
+			return e
+		}
+	}
+	return nil
+}
+
+// ghostList keeps keys of evicted entries without values, it is used by
+// [EvictionPolicyARC] and [EvictionPolicy2Q] to detect keys that were evicted too early.
+type ghostList struct {
+	// order front is the most recently evicted key.
+	order *list.List
+	keys  map[string]*list.Element
+}
+
+func newGhostList() *ghostList {
+	return &ghostList{
+		order: list.New(),
+		keys:  make(map[string]*list.Element),
+	}
+}
+
+func ghostKey(e *cacheEntry) string {
+	return e.bucket + "\x00" + e.key
+}
+
+func (g *ghostList) push(key string) {
+	if elem, ok := g.keys[key]; ok {
+		g.order.MoveToFront(elem)
+		return
+	}
+	g.keys[key] = g.order.PushFront(key)
+}
+
+// remove returns true if the key was in the list.
+func (g *ghostList) remove(key string) bool {
+	elem, ok := g.keys[key]
+	if !ok {
+		return false
+	}
+	g.order.Remove(elem)
+	delete(g.keys, key)
+	return true
+}
+
+// removeOldest drops the least recently evicted key.
+func (g *ghostList) removeOldest() {
+	if back := g.order.Back(); back != nil {
+		g.remove(back.Value.(string))
+	}
+}
+
+func (g *ghostList) len() int {
+	return g.order.Len()
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"container/heap"
+	"time"
+)
+
+var _ heap.Interface = &expirationHeap{}
+
+// expirationHeap is a min heap of entries with TTL ordered by expiration,
+// so TTL check only touches entries that actually expired instead of
+// walking every entry in every bucket.
+type expirationHeap []*cacheEntry
+
+func (h expirationHeap) Len() int { return len(h) }
+
+func (h expirationHeap) Less(i, j int) bool {
+	return h[i].expiration.Before(h[j].expiration)
+}
+
+func (h expirationHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].expireIndex = i
+	h[j].expireIndex = j
+}
+
+func (h *expirationHeap) Push(x any) {
+	e := x.(*cacheEntry)
+	e.expireIndex = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *expirationHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.expireIndex = -1
+	*h = old[:n-1]
+	return e
+}
+
+// setExpiration updates expiration of the entry and its position in the heap,
+// zero expiration removes the entry from the heap.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) setExpiration(e *cacheEntry, expiration time.Time) {
+	e.expiration = expiration
+	switch {
+	case expiration.IsZero() && e.expireIndex >= 0:
+		heap.Remove(&c.expirations, e.expireIndex)
+	case expiration.IsZero():
+	case e.expireIndex >= 0:
+		heap.Fix(&c.expirations, e.expireIndex)
+	default:
+		heap.Push(&c.expirations, e)
+	}
+}
+
+// removeExpiration is called when the entry is removed from the cache.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) removeExpiration(e *cacheEntry) {
+	if e.expireIndex >= 0 {
+		heap.Remove(&c.expirations, e.expireIndex)
+	}
+}
+
+// TTL returns the remaining TTL of the key, or [NoTTL] if the key never expires.
+func (c *LRUCache) TTL(bucket string, key string) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookup(bucket, key)
+	if err != nil {
+		c.addNotFound(err)
+		return 0, err
+	}
+	if entry.expiration.IsZero() {
+		return NoTTL, nil
+	}
+	return entry.expiration.Sub(c.clock.Now()), nil
+}
+
+// Touch sets a new TTL from now without changing the value,
+// ttl <= 0 removes the TTL like [LRUCache.Persist].
+func (c *LRUCache) Touch(bucket string, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookup(bucket, key)
+	if err != nil {
+		c.addNotFound(err)
+		return err
+	}
+	c.touch(entry, ttl)
+	return nil
+}
+
+// Persist removes the TTL of the key so it only leaves the cache by eviction or delete.
+func (c *LRUCache) Persist(bucket string, key string) error {
+	return c.Touch(bucket, key, 0)
+}
+
+// touch also updates the TTL used by refresh.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) touch(entry *cacheEntry, ttl time.Duration) {
+	if ttl <= 0 {
+		entry.opts.TTL = 0
+		c.setExpiration(entry, time.Time{})
+		return
+	}
+	entry.opts.TTL = ttl
+	c.setExpiration(entry, c.clock.Now().Add(ttl))
+}
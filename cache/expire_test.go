@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+type expireMetrics struct {
+	noopMetrics
+	lazy     int
+	active   int
+	sliding  int
+	notFound int
+}
+
+func (m *expireMetrics) AddNotFound() {
+	m.notFound++
+}
+
+func (m *expireMetrics) AddExpire(lazy bool, sliding bool) {
+	if lazy {
+		m.lazy++
+	} else {
+		m.active++
+	}
+	if sliding {
+		m.sliding++
+	}
+}
+
+func TestCheckExpired(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &expireMetrics{}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk))
+	require.NoError(t, err)
+
+	c.Set("b1", "k1", []byte("v1"), Options{TTL: time.Hour})
+	c.Set("b1", "k2", []byte("v2"), Options{TTL: time.Millisecond})
+	c.Set("b1", "k3", []byte("v3"), Options{})
+	c.Set("b2", "k1", []byte("v1"), Options{TTL: time.Millisecond})
+	c.Set("b2", "k2", []byte("v2"), Options{TTL: time.Millisecond})
+	// Remove TTL on update
+	c.Set("b2", "k2", []byte("v2"), Options{})
+	assert.Len(t, c.expirations, 3)
+
+	clk.Advance(2 * time.Millisecond)
+	c.checkExpired()
+	assert.Equal(t, 2, metrics.active)
+	assert.Len(t, c.expirations, 1)
+	assert.Equal(t, 0, c.expirations[0].expireIndex)
+	assert.Equal(t, "k1", c.expirations[0].key)
+	assert.Equal(t, 3, c.evictor.len())
+
+	// Delete removes from the heap
+	require.NoError(t, c.Delete("b1", "k1"))
+	assert.Len(t, c.expirations, 0)
+}
+
+func TestExpirationHeapOrder(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+	now := time.Now()
+	entries := make([]*cacheEntry, 5)
+	for i, d := range []int{5, 3, 4, 1, 2} {
+		entries[i] = &cacheEntry{key: string(rune('a' + i)), expireIndex: -1}
+		c.setExpiration(entries[i], now.Add(time.Duration(d)*time.Second))
+	}
+	c.setExpiration(entries[0], now)
+	c.removeExpiration(entries[3])
+
+	var order []int
+	for len(c.expirations) > 0 {
+		e := c.expirations[0]
+		order = append(order, int(e.key[0]-'a'))
+		c.removeExpiration(e)
+	}
+	assert.Equal(t, []int{0, 4, 1, 2}, order)
+}
+
+func TestTouch(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c := newTestCache(t, 10, 0, WithClock(clk))
+
+	_, err := c.TTL("b1", "k1")
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	assert.ErrorIs(t, c.Touch("b1", "k1", time.Second), ErrBucketNotFound)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{}))
+	ttl, err := c.TTL("b1", "k1")
+	require.NoError(t, err)
+	assert.Equal(t, NoTTL, ttl)
+
+	require.NoError(t, c.Touch("b1", "k1", time.Second))
+	clk.Advance(400 * time.Millisecond)
+	ttl, err = c.TTL("b1", "k1")
+	require.NoError(t, err)
+	assert.Equal(t, 600*time.Millisecond, ttl)
+
+	// Extend before expired
+	require.NoError(t, c.Touch("b1", "k1", time.Second))
+	clk.Advance(900 * time.Millisecond)
+	v, err := c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), v)
+
+	require.NoError(t, c.Persist("b1", "k1"))
+	assert.Empty(t, c.expirations)
+	clk.Advance(time.Hour)
+	ttl, err = c.TTL("b1", "k1")
+	require.NoError(t, err)
+	assert.Equal(t, NoTTL, ttl)
+
+	// Can't touch expired key
+	require.NoError(t, c.Set("b1", "k2", []byte("v2"), Options{TTL: time.Second}))
+	clk.Advance(2 * time.Second)
+	assert.ErrorIs(t, c.Touch("b1", "k2", time.Second), ErrExpired)
+}
+
+func TestTouchExpired(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &expireMetrics{}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{TTL: time.Second}))
+	require.NoError(t, c.Set("b1", "k2", []byte("v2"), Options{TTL: time.Second}))
+	clk.Advance(2 * time.Second)
+	// Counted as expire like Get, not as miss
+	_, err = c.TTL("b1", "k1")
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.ErrorIs(t, c.Touch("b1", "k2", time.Second), ErrExpired)
+	assert.Equal(t, 2, metrics.lazy)
+	assert.Equal(t, 0, metrics.notFound)
+
+	_, err = c.TTL("b1", "k1")
+	assert.True(t, IsMiss(err))
+	assert.Equal(t, 1, metrics.notFound)
+}
+
+func TestSlidingTTL(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &expireMetrics{}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk), WithEvictionPolicy(EvictionPolicySIEVE))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("b1", "session", []byte("v1"), Options{TTL: time.Second, Sliding: true}))
+	require.NoError(t, c.Set("b1", "absolute", []byte("v2"), Options{TTL: time.Second}))
+
+	// Get extends sliding TTL, even for SIEVE that serves Get under read lock
+	for range 3 {
+		clk.Advance(600 * time.Millisecond)
+		_, err = c.Get("b1", "session", Options{})
+		require.NoError(t, err)
+	}
+	_, err = c.Get("b1", "absolute", Options{})
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.Equal(t, 0, metrics.sliding)
+
+	ttl, err := c.TTL("b1", "session")
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, ttl, "TTL does not slide")
+
+	// Expires when not used
+	clk.Advance(2 * time.Second)
+	c.checkExpired()
+	assert.Equal(t, 1, metrics.active)
+	assert.Equal(t, 1, metrics.sliding)
+	_, err = c.Get("b1", "session", Options{})
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+}
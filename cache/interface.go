@@ -1,9 +1,16 @@
 package cache
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/at15/tinycache/cache/clock"
 )
 
 type EvictionPolicy int
@@ -14,65 +21,356 @@ const (
 	EvictionPolicyNewest
 	EvictionPolicyLRU
 	EvictionPolicyMRU
+	// EvictionPolicyLFU evicts the least frequently used key,
+	// keys with same frequency are evicted in LRU order.
+	EvictionPolicyLFU
+	// EvictionPolicyTinyLFU uses Window-TinyLFU, new keys are only admitted
+	// when they are used more frequently than the key they replace.
+	EvictionPolicyTinyLFU
+	// EvictionPolicyARC uses Adaptive Replacement Cache that balances
+	// between recency and frequency using ghost lists of evicted keys.
+	EvictionPolicyARC
+	// EvictionPolicy2Q keeps keys used only once in a separate queue
+	// so they don't evict frequently used keys.
+	EvictionPolicy2Q
+	// EvictionPolicySIEVE and EvictionPolicyCLOCK only set a visited bit on hit,
+	// so Get does not need the write lock.
+	EvictionPolicySIEVE
+	EvictionPolicyCLOCK
 )
 
+// String returns the name used in command line flag and http query.
+func (p EvictionPolicy) String() string {
+	switch p {
+	case EvictionPolicyNone:
+		return "none"
+	case EvictionPolicyOldest:
+		return "oldest"
+	case EvictionPolicyNewest:
+		return "newest"
+	case EvictionPolicyLRU:
+		return "lru"
+	case EvictionPolicyMRU:
+		return "mru"
+	case EvictionPolicyLFU:
+		return "lfu"
+	case EvictionPolicyTinyLFU:
+		return "tinylfu"
+	case EvictionPolicyARC:
+		return "arc"
+	case EvictionPolicy2Q:
+		return "2q"
+	case EvictionPolicySIEVE:
+		return "sieve"
+	case EvictionPolicyCLOCK:
+		return "clock"
+	default:
+		return fmt.Sprintf("EvictionPolicy(%d)", int(p))
+	}
+}
+
+// ParseEvictionPolicy is the reverse of [EvictionPolicy.String].
+func ParseEvictionPolicy(policy string) (EvictionPolicy, error) {
+	switch policy {
+	case "none":
+		return EvictionPolicyNone, nil
+	case "lru":
+		return EvictionPolicyLRU, nil
+	case "mru":
+		return EvictionPolicyMRU, nil
+	case "oldest":
+		return EvictionPolicyOldest, nil
+	case "newest":
+		return EvictionPolicyNewest, nil
+	case "lfu":
+		return EvictionPolicyLFU, nil
+	case "tinylfu":
+		return EvictionPolicyTinyLFU, nil
+	case "arc":
+		return EvictionPolicyARC, nil
+	case "2q":
+		return EvictionPolicy2Q, nil
+	case "sieve":
+		return EvictionPolicySIEVE, nil
+	case "clock":
+		return EvictionPolicyCLOCK, nil
+	default:
+		return EvictionPolicyNone, fmt.Errorf("invalid policy: %s", policy)
+	}
+}
+
+// NoTTL is returned by [Cache.TTL] for key without TTL.
+const NoTTL time.Duration = -1
+
+// Options applies to a single operation.
+// Eviction policy is configured for the entire cache using [WithEvictionPolicy].
 type Options struct {
-	TTL            time.Duration
-	EvictionPolicy EvictionPolicy
+	TTL time.Duration
+	// SoftTTL marks the entry stale before TTL, stale entry is still returned
+	// while it is refreshed in the background using [WithLoader].
+	// 0 means no refresh, it has no effect if the cache has no loader.
+	SoftTTL time.Duration
+	// Beta enables probabilistic early refresh (XFetch) before SoftTTL for entries
+	// set by a loader, larger value refreshes earlier, 1 is a good start.
+	// 0 means only refresh after SoftTTL.
+	Beta float64
+	// Sliding resets TTL on every Get hit, so the key only expires when it is not
+	// used for TTL e.g. session. SoftTTL does not slide.
+	Sliding bool
+	// Tags of the entry for [Cache.InvalidateTag], they are replaced on every set.
+	Tags []string
 }
 
+// ParseFromRequest parses [Options] from query parameters, tags are also read from X-Cache-Tags header.
 func ParseFromRequest(r *http.Request) (Options, error) {
-	ttl := r.URL.Query().Get("ttl")
-	if ttl == "" {
-		ttl = "0"
+	q := r.URL.Query()
+	for _, s := range r.Header.Values("X-Cache-Tags") {
+		q.Add("tags", s)
+	}
+	return ParseFromQuery(q)
+}
+
+// ParseFromQuery parses ttl, soft_ttl, beta, sliding and comma separated tags.
+func ParseFromQuery(q url.Values) (Options, error) {
+	ttl, err := parseDuration(q, "ttl")
+	if err != nil {
+		return Options{}, err
 	}
-	// "300ms", "-1.5h" or "2h45m".
-	ttlDuration, err := time.ParseDuration(ttl)
+	softTTL, err := parseDuration(q, "soft_ttl")
 	if err != nil {
 		return Options{}, err
 	}
-	if ttlDuration < 0 {
-		return Options{}, fmt.Errorf("ttl cannot be negative: %s", ttl)
+	if ttl > 0 && softTTL > ttl {
+		return Options{}, fmt.Errorf("soft_ttl %s cannot be longer than ttl %s", softTTL, ttl)
+	}
+	beta := 0.0
+	if s := q.Get("beta"); s != "" {
+		beta, err = strconv.ParseFloat(s, 64)
+		if err != nil {
+			return Options{}, err
+		}
+		if beta < 0 || math.IsNaN(beta) || math.IsInf(beta, 0) {
+			return Options{}, fmt.Errorf("beta must be a non negative number: %s", s)
+		}
 	}
 
-	policy := r.URL.Query().Get("policy")
-	if policy == "" {
-		policy = "lru"
+	var tags []string
+	for _, s := range q["tags"] {
+		for _, tag := range strings.Split(s, ",") {
+			tags = append(tags, strings.TrimSpace(tag))
+		}
 	}
 
-	var evictionPolicy EvictionPolicy
-	switch policy {
-	case "lru":
-		evictionPolicy = EvictionPolicyLRU
-	case "mru":
-		evictionPolicy = EvictionPolicyMRU
-	case "oldest":
-		evictionPolicy = EvictionPolicyOldest
-	case "newest":
-		evictionPolicy = EvictionPolicyNewest
-	default:
-		return Options{}, fmt.Errorf("invalid policy: %s", policy)
+	sliding := false
+	if s := q.Get("sliding"); s != "" {
+		sliding, err = strconv.ParseBool(s)
+		if err != nil {
+			return Options{}, err
+		}
+		if sliding && ttl == 0 {
+			return Options{}, fmt.Errorf("sliding requires ttl")
+		}
 	}
 
 	return Options{
-		TTL:            ttlDuration,
-		EvictionPolicy: evictionPolicy,
+		TTL:     ttl,
+		SoftTTL: softTTL,
+		Beta:    beta,
+		Sliding: sliding,
+		Tags:    tags,
 	}, nil
 }
 
-// TODO: the interface seems to be wrong, should be func(o *Options) error or return a new Options to allow modifying the default option
-// type Option func(o Options) error
-// type Option func(*Options) error
+// parseDuration parses non negative duration like "300ms" or "2h45m", empty is 0.
+func parseDuration(q url.Values, name string) (time.Duration, error) {
+	s := q.Get(name)
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s cannot be negative: %s", name, s)
+	}
+	return d, nil
+}
 
-// Cache interface that only has one implementation ... [LRUCache]
-type Cache interface {
-	// TODO: options interface provided is likely wrong
-	// TODO: different eviction policy for each bucket + key? shouldn't entire cache have the same eviction policy?
-	// Set(bucket string, key string, value []byte, opts ...Option) error
-	// Get(bucket string, key string, opts ...Option) ([]byte, error)
-	// Delete(bucket string, key string, opts ...Option) error
+// config is the cache wide configuration, it is modified by [Option]
+// when creating the cache.
+type config struct {
+	evictionPolicy EvictionPolicy
+	maxBytes       int64
+	// defaultBucketConfig applies to buckets without [Cache.ConfigureBucket].
+	defaultBucketConfig BucketConfig
+	clock               clock.Clock
+	loader              Loader
+	absentTTL           time.Duration
+}
+
+func defaultConfig() config {
+	return config{
+		evictionPolicy: EvictionPolicyLRU,
+		clock:          clock.Real{},
+	}
+}
+
+// Option changes the default cache wide configuration.
+type Option func(c *config) error
+
+// WithEvictionPolicy sets the eviction policy for the entire cache.
+// Default is [EvictionPolicyLRU].
+func WithEvictionPolicy(policy EvictionPolicy) Option {
+	return func(c *config) error {
+		c.evictionPolicy = policy
+		return nil
+	}
+}
+
+// WithMaxBytes limits the total size of bucket, key and value of all the entries.
+// Entries are evicted until the new entry fits, value larger than the limit
+// is rejected with [ErrTooLarge]. Default is 0 which means no limit.
+func WithMaxBytes(maxBytes int64) Option {
+	return func(c *config) error {
+		if maxBytes < 0 {
+			return fmt.Errorf("max bytes cannot be negative: %d", maxBytes)
+		}
+		c.maxBytes = maxBytes
+		return nil
+	}
+}
 
+// WithDefaultBucketConfig limits every bucket that is not configured
+// using [Cache.ConfigureBucket]. Default is no limit.
+func WithDefaultBucketConfig(bucketCfg BucketConfig) Option {
+	return func(c *config) error {
+		if err := bucketCfg.validate(); err != nil {
+			return err
+		}
+		c.defaultBucketConfig = bucketCfg
+		return nil
+	}
+}
+
+// WithClock replaces the time package for TTL, use clocktest.Fake in tests.
+// Default is [clock.Real].
+func WithClock(clk clock.Clock) Option {
+	return func(c *config) error {
+		c.clock = clk
+		return nil
+	}
+}
+
+// WithLoader refreshes entries past [Options.SoftTTL] in the background.
+// It is not used on miss, use [Cache.GetOrLoad] to load missing keys.
+func WithLoader(loader Loader) Option {
+	return func(c *config) error {
+		c.loader = loader
+		return nil
+	}
+}
+
+// DefaultAbsentTTL is the TTL of [Cache.SetAbsent] when neither [Options.TTL]
+// nor [WithAbsentTTL] is set, known absent key always expires.
+const DefaultAbsentTTL = time.Minute
+
+// WithAbsentTTL caches [ErrNotFound] returned by the loader of [Cache.GetOrLoad]
+// as known absent for ttl, it is also the default TTL of [Cache.SetAbsent].
+// It is usually shorter than TTL of values. Default is 0 which means
+// loader errors are not cached and SetAbsent uses [DefaultAbsentTTL].
+func WithAbsentTTL(ttl time.Duration) Option {
+	return func(c *config) error {
+		if ttl < 0 {
+			return fmt.Errorf("absent ttl cannot be negative: %s", ttl)
+		}
+		c.absentTTL = ttl
+		return nil
+	}
+}
+
+func applyOptions(opts []Option) (config, error) {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// Cache is implemented by [LRUCache] and [ShardedCache] that splits keys into multiple [LRUCache].
+type Cache interface {
 	Set(bucket string, key string, value []byte, opts Options) error
 	Get(bucket string, key string, opts Options) ([]byte, error)
 	Delete(bucket string, key string) error
+	// GetWithVersion also returns the version of the value, version changes
+	// on every update of the key and only increases.
+	GetWithVersion(bucket string, key string, opts Options) ([]byte, uint64, error)
+	// CompareAndSet only sets the value if the key still has the version,
+	// 0 means the key must not exist. It returns the new version.
+	CompareAndSet(bucket string, key string, value []byte, version uint64, opts Options) (uint64, error)
+	// CompareAndDelete only deletes the key if it still has the version.
+	CompareAndDelete(bucket string, key string, version uint64) error
+	// SetNX only sets the value if the key does not exist, it returns false if the key exists.
+	SetNX(bucket string, key string, value []byte, opts Options) (bool, error)
+	// SetXX only sets the value if the key exists, it returns false if the key does not exist.
+	SetXX(bucket string, key string, value []byte, opts Options) (bool, error)
+	// GetSet sets the value and returns the old value, it returns false if the key did not exist.
+	GetSet(bucket string, key string, value []byte, opts Options) ([]byte, bool, error)
+	// Increment atomically adds delta (negative to decrement) to the integer value
+	// and returns the result, missing key starts from initial.
+	Increment(bucket string, key string, delta int64, initial int64, opts Options) (int64, error)
+	// SetAbsent caches the key as known absent (negative caching),
+	// Get returns [ErrAbsent] instead of a miss until TTL, see [DefaultAbsentTTL].
+	SetAbsent(bucket string, key string, opts Options) error
+	// TTL returns the remaining TTL of the key, or [NoTTL] if the key never expires.
+	TTL(bucket string, key string) (time.Duration, error)
+	// Touch sets a new TTL without changing the value, ttl <= 0 is the same as Persist.
+	Touch(bucket string, key string, ttl time.Duration) error
+	// Persist removes the TTL of the key.
+	Persist(bucket string, key string) error
+	// MGet gets multiple keys under the lock once, results are in the same order as keys.
+	MGet(keys []BatchKey, opts Options) []BatchResult
+	// MSet sets multiple values under the lock once, nil error means the item is set.
+	MSet(items []BatchItem) []error
+	// MDelete deletes multiple keys under the lock once, nil error means the key is deleted.
+	MDelete(keys []BatchKey) []error
+	// Transaction applies all the ops or none of them, ops can be guarded by versions from GetWithVersion.
+	Transaction(ops []TxOp) ([]TxResult, error)
+	// GetOrLoad calls loader on miss and caches the value, concurrent loads of
+	// the same key are collapsed into one call.
+	GetOrLoad(ctx context.Context, bucket string, key string, opts Options, loader Loader) ([]byte, error)
+
+	// ConfigureBucket limits a single bucket, keys are evicted from the bucket
+	// when it reaches its limit, see [BucketConfig].
+	ConfigureBucket(bucket string, cfg BucketConfig) error
+	// BucketConfig returns limits of the bucket, default from
+	// [WithDefaultBucketConfig] is returned if the bucket is not configured.
+	BucketConfig(bucket string) BucketConfig
+	// ListBuckets returns names of non empty buckets in sorted order.
+	ListBuckets() []string
+	// BucketStats returns number of keys, bytes and time range of the bucket.
+	BucketStats(bucket string) (BucketStats, error)
+	// Scan returns a page of keys matching the glob pattern and the cursor of next page,
+	// empty cursor means start or the end.
+	Scan(bucket string, cursor string, match string, count int) ([]string, string, error)
+	// DeleteByPrefix deletes keys starting with prefix in the bucket and returns the number of deleted keys.
+	DeleteByPrefix(bucket string, prefix string) int
+	// DeleteByPattern deletes keys matching the glob pattern of Scan and returns the number of deleted keys.
+	DeleteByPattern(bucket string, pattern string) int
+	// InvalidateTag deletes entries with the tag in all the buckets and returns the number of deleted entries.
+	InvalidateTag(tag string) int
+	// FlushBucket deletes all the keys in the bucket and returns the number of deleted keys.
+	FlushBucket(bucket string) (int, error)
+	// Flush deletes all the keys in the cache and returns the number of deleted keys.
+	Flush() int
+
+	// EvictionPolicy returns the policy configured when creating the cache.
+	EvictionPolicy() EvictionPolicy
+	// HasLoader returns true if the cache is created with [WithLoader],
+	// [Options.SoftTTL] and [Options.Beta] have no effect without a loader.
+	HasLoader() bool
+	// Stop background go routines e.g. TTL check.
+	Stop()
 }
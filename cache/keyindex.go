@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"math/rand/v2"
+)
+
+// maxKeyIndexLevel allows 4^32 keys with O(log n) insert, remove and seek.
+const maxKeyIndexLevel = 32
+
+// keyIndex keeps keys of a bucket in sorted order for Scan, so a page starts
+// from the cursor instead of walking the entire bucket.
+// It is a skip list, see "Skip Lists: A Probabilistic Alternative to Balanced Trees".
+// NOTE: It has no lock, caller must hold the lock of the cache.
+type keyIndex struct {
+	head  keyNode
+	level int
+}
+
+type keyNode struct {
+	key  string
+	next []*keyNode
+}
+
+func newKeyIndex() *keyIndex {
+	return &keyIndex{head: keyNode{next: make([]*keyNode, maxKeyIndexLevel)}, level: 1}
+}
+
+// insert adds the key if it does not exist.
+func (s *keyIndex) insert(key string) {
+	var prev [maxKeyIndexLevel]*keyNode
+	if n := s.find(key, &prev); n != nil && n.key == key {
+		return
+	}
+
+	level := 1
+	for level < maxKeyIndexLevel && rand.IntN(4) == 0 {
+		level++
+	}
+	for i := s.level; i < level; i++ {
+		prev[i] = &s.head
+	}
+	s.level = max(s.level, level)
+	n := &keyNode{key: key, next: make([]*keyNode, level)}
+	for i := range level {
+		n.next[i] = prev[i].next[i]
+		prev[i].next[i] = n
+	}
+}
+
+// remove deletes the key if it exists.
+func (s *keyIndex) remove(key string) {
+	var prev [maxKeyIndexLevel]*keyNode
+	n := s.find(key, &prev)
+	if n == nil || n.key != key {
+		return
+	}
+	for i := range n.next {
+		prev[i].next[i] = n.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+}
+
+// first returns the node of the smallest key, nil if the index is empty.
+func (s *keyIndex) first() *keyNode {
+	return s.head.next[0]
+}
+
+// after returns the node of the smallest key larger than key, nil if there is none.
+func (s *keyIndex) after(key string) *keyNode {
+	n := s.find(key, nil)
+	if n != nil && n.key == key {
+		return n.next[0]
+	}
+	return n
+}
+
+// find returns the node of the smallest key not less than key and records
+// the last node before it on each level in prev if it is not nil.
+func (s *keyIndex) find(key string, prev *[maxKeyIndexLevel]*keyNode) *keyNode {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+		if prev != nil {
+			prev[i] = x
+		}
+	}
+	return x.next[0]
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// indexKeys returns all the keys in the index in order.
+func indexKeys(idx *keyIndex) []string {
+	var keys []string
+	for n := idx.first(); n != nil; n = n.next[0] {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+func TestKeyIndex(t *testing.T) {
+	idx := newKeyIndex()
+	assert.Nil(t, idx.first())
+	assert.Nil(t, idx.after(""))
+
+	// Compare with a sorted set after random inserts and removes
+	want := make(map[string]struct{})
+	for range 5000 {
+		key := fmt.Sprintf("k%03d", rand.IntN(500))
+		if rand.IntN(3) == 0 {
+			idx.remove(key)
+			delete(want, key)
+		} else {
+			idx.insert(key)
+			want[key] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(want))
+	for key := range want {
+		sorted = append(sorted, key)
+	}
+	slices.Sort(sorted)
+	require.Equal(t, sorted, indexKeys(idx))
+
+	for i, key := range sorted {
+		n := idx.after(key)
+		if i == len(sorted)-1 {
+			assert.Nil(t, n)
+		} else {
+			assert.Equal(t, sorted[i+1], n.key)
+		}
+	}
+	assert.Equal(t, sorted[0], idx.after("").key)
+	assert.Equal(t, sorted[0], idx.after(sorted[0][:2]).key, "key does not need to exist")
+
+	for _, key := range sorted {
+		idx.remove(key)
+	}
+	assert.Nil(t, idx.first())
+	assert.Equal(t, 1, idx.level)
+}
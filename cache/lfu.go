@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"container/list"
+)
+
+var _ evictor = &lfuEvictor{}
+
+// lfuEvictor implements [EvictionPolicyLFU] with O(1) add, access and evict
+// using frequency buckets from http://dhruvbird.com/lfu.pdf
+// Entries with the same frequency are evicted in LRU order.
+type lfuEvictor struct {
+	// freqs is sorted by count in ascending order, value is *lfuNode.
+	freqs *list.List
+	size  int
+}
+
+// lfuNode holds all the entries with the same access count.
+type lfuNode struct {
+	count int
+	// entries front is the least recently used.
+	entries *list.List
+}
+
+func newLFUEvictor() *lfuEvictor {
+	return &lfuEvictor{
+		freqs: list.New(),
+	}
+}
+
+func (l *lfuEvictor) add(e *cacheEntry) {
+	front := l.freqs.Front()
+	if front == nil || front.Value.(*lfuNode).count != 1 {
+		front = l.freqs.PushFront(&lfuNode{count: 1, entries: list.New()})
+	}
+	e.freq = front
+	e.elem = front.Value.(*lfuNode).entries.PushBack(e)
+	l.size++
+}
+
+func (l *lfuEvictor) access(e *cacheEntry) {
+	cur := e.freq
+	node := cur.Value.(*lfuNode)
+	next := cur.Next()
+	if next == nil || next.Value.(*lfuNode).count != node.count+1 {
+		next = l.freqs.InsertAfter(&lfuNode{count: node.count + 1, entries: list.New()}, cur)
+	}
+
+	node.entries.Remove(e.elem)
+	if node.entries.Len() == 0 {
+		l.freqs.Remove(cur)
+	}
+	e.freq = next
+	e.elem = next.Value.(*lfuNode).entries.PushBack(e)
+}
+
+func (l *lfuEvictor) remove(e *cacheEntry) {
+	node := e.freq.Value.(*lfuNode)
+	node.entries.Remove(e.elem)
+	if node.entries.Len() == 0 {
+		l.freqs.Remove(e.freq)
+	}
+	e.freq = nil
+	e.elem = nil
+	l.size--
+}
+
+func (l *lfuEvictor) victim() *cacheEntry {
+	front := l.freqs.Front()
+	if front == nil {
+		return nil
+	}
+	return front.Value.(*lfuNode).entries.Front().Value.(*cacheEntry)
+}
+
+func (l *lfuEvictor) victimIn(bucket string) *cacheEntry {
+	for elem := l.freqs.Front(); elem != nil; elem = elem.Next() {
+		if e := firstIn(elem.Value.(*lfuNode).entries, bucket); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (l *lfuEvictor) len() int {
+	return l.size
+}
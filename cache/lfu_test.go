@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFUEvictor(t *testing.T) {
+	l := newLFUEvictor()
+	entries := make([]*cacheEntry, 3)
+	for i := range entries {
+		entries[i] = &cacheEntry{key: fmt.Sprintf("k%d", i)}
+		l.add(entries[i])
+	}
+	assert.Equal(t, 3, l.len())
+	// Same frequency, evict in LRU order
+	assert.Equal(t, entries[0], l.victim())
+
+	l.access(entries[0])
+	l.access(entries[0])
+	l.access(entries[1])
+	assert.Equal(t, entries[2], l.victim())
+	// count 1: k2, count 2: k1, count 3: k0
+	assert.Equal(t, 3, l.freqs.Len())
+
+	l.remove(entries[2])
+	assert.Equal(t, entries[1], l.victim())
+	l.remove(entries[1])
+	assert.Equal(t, entries[0], l.victim())
+	assert.Equal(t, 1, l.freqs.Len())
+	l.remove(entries[0])
+	assert.Nil(t, l.victim())
+	assert.Equal(t, 0, l.len())
+}
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Loader loads the value on cache miss e.g. from database.
+type Loader func(ctx context.Context, bucket string, key string) ([]byte, error)
+
+// NewHTTPLoader loads the value from GET baseURL/{bucket}/{key} of an origin server,
+// 404 is returned as [ErrNotFound] and other non 2xx status is an error.
+// It allows the server to refresh entries with [Options.SoftTTL] using [WithLoader].
+func NewHTTPLoader(baseURL string, client *http.Client) Loader {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		u := baseURL + "/" + url.PathEscape(bucket) + "/" + url.PathEscape(key)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode == http.StatusNotFound {
+			return nil, keyError(bucket, key, ErrNotFound)
+		}
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return nil, fmt.Errorf("load %s: unexpected status %s", u, res.Status)
+		}
+		return io.ReadAll(res.Body)
+	}
+}
+
+// GetOrLoad returns the value from cache, on miss it calls loader and caches the value
+// using opts. Concurrent loads of the same bucket and key are collapsed into one call,
+// callers waiting for the load return early if their ctx is done, and load again
+// if ctx of the caller running the loader is done. Panic in loader is raised in all the callers.
+// Error from loader is returned as is and not cached, except [ErrNotFound] that is
+// cached as known absent and returned as [ErrAbsent] when [WithAbsentTTL] is set.
+func (c *LRUCache) GetOrLoad(ctx context.Context, bucket string, key string, opts Options, loader Loader) ([]byte, error) {
+	value, err := c.Get(bucket, key, opts)
+	if err == nil || !IsMiss(err) {
+		return value, err
+	}
+
+	return c.loads.do(ctx, bucket+"\x00"+key, func() ([]byte, error) {
+		// Another load for the same key may have finished after our Get
+		if value, absent, ok := c.peek(bucket, key); ok {
+			if absent {
+				return nil, keyError(bucket, key, ErrAbsent)
+			}
+			return value, nil
+		}
+
+		return c.load(ctx, bucket, key, opts, loader, nil)
+	})
+}
+
+// load calls loader and caches the value, it is used by GetOrLoad and refresh.
+// If version is not nil, the value is only cached if the key still has the version
+// like [LRUCache.CompareAndSet], otherwise it returns [ErrVersionMismatch].
+func (c *LRUCache) load(ctx context.Context, bucket string, key string, opts Options, loader Loader, version *uint64) ([]byte, error) {
+	start := c.clock.Now()
+	value, err := loader(ctx, bucket, key)
+	latency := c.clock.Now().Sub(start)
+	c.metrics.ObserveLoad(latency)
+	if err != nil && (c.absentTTL <= 0 || !errors.Is(err, ErrNotFound)) {
+		c.metrics.AddLoadError()
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != nil && c.currentVersion(bucket, key) != *version {
+		return nil, keyError(bucket, key, ErrVersionMismatch)
+	}
+	defer c.metrics.AddSet()
+	if err != nil {
+		_ = c.setAbsent(bucket, key, Options{})
+		return nil, keyError(bucket, key, ErrAbsent)
+	}
+
+	// Loaded value is still returned if it can't be cached e.g. too large.
+	if entry, err := c.set(bucket, key, value, opts); err == nil {
+		entry.loadTime = latency
+	}
+	return value, nil
+}
+
+// peek returns the value if it exists and not expired without updating
+// usage order and metrics.
+func (c *LRUCache) peek(bucket string, key string) (value []byte, absent bool, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.buckets[bucket][key]
+	if !ok || entry.expired(c.clock.Now()) {
+		return nil, false, false
+	}
+	return entry.value, entry.absent, true
+}
+
+// loadGroup collapses concurrent loads of the same key like
+// golang.org/x/sync/singleflight, it only deals with []byte.
+type loadGroup struct {
+	mu    sync.Mutex
+	calls map[string]*loadCall
+}
+
+type loadCall struct {
+	// done is closed when value and err are set.
+	done  chan struct{}
+	value []byte
+	err   error
+	// panicked is recovered from fn and panics again in all the callers.
+	panicked *loadPanic
+	// canceled is true if fn failed after ctx of the caller running it is done,
+	// waiters retry with their own ctx instead of returning the error.
+	canceled bool
+	// waiters is the number of callers that joined the call, it is guarded by mu of loadGroup.
+	waiters int
+}
+
+// loadPanic keeps the stack of the goroutine running the loader, the panic
+// is raised again in other goroutines where the original stack is lost.
+type loadPanic struct {
+	value any
+	stack []byte
+}
+
+func (p *loadPanic) Error() string {
+	return fmt.Sprintf("%v\n\n%s", p.value, p.stack)
+}
+
+// do calls fn if there is no load in flight for the key, otherwise it waits for
+// the result of the load in flight.
+func (g *loadGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) ([]byte, error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*loadCall)
+		}
+		if call, ok := g.calls[key]; ok {
+			call.waiters++
+			g.mu.Unlock()
+			select {
+			case <-call.done:
+				if call.panicked != nil {
+					panic(call.panicked)
+				}
+				if call.canceled {
+					continue
+				}
+				return call.value, call.err
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		call := &loadCall{done: make(chan struct{})}
+		g.calls[key] = call
+		g.mu.Unlock()
+
+		g.call(ctx, key, call, fn)
+		if call.panicked != nil {
+			panic(call.panicked)
+		}
+		return call.value, call.err
+	}
+}
+
+// call runs fn and releases the waiters even if fn panics.
+func (g *loadGroup) call(ctx context.Context, key string, call *loadCall, fn func() ([]byte, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.panicked = &loadPanic{value: r, stack: debug.Stack()}
+		}
+		// Remove before close so retrying waiters start a new load
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+	call.canceled = call.err != nil && ctx.Err() != nil
+}
@@ -0,0 +1,242 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+type loadMetrics struct {
+	noopMetrics
+	mu         sync.Mutex
+	loads      []time.Duration
+	loadErrors int
+}
+
+func (m *loadMetrics) ObserveLoad(latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loads = append(m.loads, latency)
+}
+
+func (m *loadMetrics) AddLoadError() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loadErrors++
+}
+
+func TestGetOrLoad(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &loadMetrics{}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk))
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		calls.Add(1)
+		clk.Advance(5 * time.Millisecond)
+		return []byte(bucket + "/" + key), nil
+	}
+	v, err := c.GetOrLoad(context.Background(), "b1", "k1", Options{TTL: time.Second}, loader)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b1/k1"), v)
+	assert.Equal(t, []time.Duration{5 * time.Millisecond}, metrics.loads)
+
+	// Cached
+	v, err = c.GetOrLoad(context.Background(), "b1", "k1", Options{TTL: time.Second}, loader)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b1/k1"), v)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Reload after TTL
+	clk.Advance(2 * time.Second)
+	_, err = c.GetOrLoad(context.Background(), "b1", "k1", Options{TTL: time.Second}, loader)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestGetOrLoadError(t *testing.T) {
+	metrics := &loadMetrics{}
+	c, err := NewLRUCache(10, 0, metrics)
+	require.NoError(t, err)
+
+	errDB := errors.New("db is down")
+	_, err = c.GetOrLoad(context.Background(), "b1", "k1", Options{}, func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		return nil, errDB
+	})
+	assert.ErrorIs(t, err, errDB)
+	assert.Equal(t, 1, metrics.loadErrors)
+
+	// Error is not cached
+	_, err = c.Get("b1", "k1", Options{})
+	assert.True(t, IsMiss(err))
+}
+
+func TestGetOrLoadDeduplicate(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		calls.Add(1)
+		close(started)
+		<-release
+		return []byte("v1"), nil
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		v, err := c.GetOrLoad(context.Background(), "b1", "k1", Options{}, loader)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("v1"), v)
+	}()
+	<-started
+
+	// Waiters join the load in flight
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad(context.Background(), "b1", "k1", Options{}, loader)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("v1"), v)
+		}()
+	}
+
+	// Waiter with canceled context returns early
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetOrLoad(ctx, "b1", "k1", Options{}, loader)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	waitJoined(t, &c.loads, "b1\x00k1", 11)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// waitJoined waits until n callers joined the load of key in flight.
+func waitJoined(t *testing.T, g *loadGroup, key string, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		call, ok := g.calls[key]
+		return ok && call.waiters == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestGetOrLoadPanic(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+
+	var once sync.Once
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		once.Do(func() { close(started) })
+		<-release
+		panic("loader bug")
+	}
+	getOrLoad := func() (panicked any) {
+		defer func() { panicked = recover() }()
+		_, _ = c.GetOrLoad(context.Background(), "b1", "k1", Options{}, loader)
+		return nil
+	}
+
+	leader := make(chan any)
+	go func() { leader <- getOrLoad() }()
+	<-started
+	waiter := make(chan any)
+	go func() { waiter <- getOrLoad() }()
+	waitJoined(t, &c.loads, "b1\x00k1", 1)
+	close(release)
+
+	for _, ch := range []chan any{leader, waiter} {
+		p := <-ch
+		require.NotNil(t, p)
+		assert.Contains(t, p.(error).Error(), "loader bug")
+	}
+
+	// Later load is not blocked by the panicked one
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	v, err := c.GetOrLoad(ctx, "b1", "k1", Options{}, func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		return []byte("v1"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), v)
+}
+
+func TestGetOrLoadCanceledLeader(t *testing.T) {
+	c := newTestCache(t, 10, 0)
+
+	var calls atomic.Int32
+	started := make(chan struct{})
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []byte("v1"), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := c.GetOrLoad(ctx, "b1", "k1", Options{}, loader)
+		leader <- err
+	}()
+	<-started
+
+	waiter := make(chan []byte)
+	go func() {
+		v, err := c.GetOrLoad(context.Background(), "b1", "k1", Options{}, loader)
+		assert.NoError(t, err)
+		waiter <- v
+	}()
+	waitJoined(t, &c.loads, "b1\x00k1", 1)
+	cancel()
+
+	assert.ErrorIs(t, <-leader, context.Canceled)
+	// Waiter loads again with its own ctx
+	assert.Equal(t, []byte("v1"), <-waiter)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHTTPLoader(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/origin/b1/k%2F1":
+			w.Write([]byte("v1"))
+		case "/origin/b1/down":
+			http.Error(w, "db is down", http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer origin.Close()
+	loader := NewHTTPLoader(origin.URL+"/origin/", origin.Client())
+
+	v, err := loader(context.Background(), "b1", "k/1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), v)
+	_, err = loader(context.Background(), "b1", "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = loader(context.Background(), "b1", "down")
+	assert.ErrorContains(t, err, "500")
+	assert.False(t, IsMiss(err))
+}
@@ -2,9 +2,12 @@ package cache
 
 import (
 	"container/list"
-	"fmt"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/at15/tinycache/cache/clock"
 )
 
 var _ Cache = &LRUCache{}
@@ -12,22 +15,51 @@ var _ Cache = &LRUCache{}
 // LRUCache implements a [Cache] that supports different [EvictionPolicy].
 // LRU instead of Lru https://google.github.io/styleguide/go/decisions.html#initialisms
 type LRUCache struct {
-	capacity         int
+	capacity int
+	// maxBytes limits the total size of bucket, key and value, 0 means no limit.
+	maxBytes int64
+	// bytes is the current total size of all the entries.
+	bytes            int64
+	policy           EvictionPolicy
 	ttlCheckInterval time.Duration
 	stop             chan struct{}
 	metrics          MetricsHandler
-	// mu locks all the buckets and the order list.
-	// We don't use a RWMutex because even read operation
-	// can do updates due to evict and updating usage order.
+	clock            clock.Clock
+	// mu locks all the buckets and the evictor.
+	// Most evictors update usage order even for read operation, so Get
+	// needs the write lock, except for [sharedAccessEvictor].
 	// Appling lock per bucket is also over complicated due to updating order list.
-	mu sync.Mutex
-	// buckets maps to key values where value is a pointer to an element in the order list.
-	// The actual value is stored in the [list.Element] Value field.
-	buckets map[string]map[string]*list.Element
-	// order is by default the insertion order
-	// If user use [EvictionPolicyLRU] or [EvictionPolicyMRU], then the order is also updated
-	// during Get and Set.
-	order *list.List
+	mu sync.RWMutex
+	// buckets maps to key values where value is a pointer to the entry
+	// that is also tracked by the evictor.
+	buckets map[string]map[string]*cacheEntry
+	// expirations only contains entries with TTL, earliest expiration is on top.
+	expirations expirationHeap
+	// evictor tracks the order of entries based on the [EvictionPolicy]
+	// and decides which entry to evict when capacity is reached.
+	evictor evictor
+	// sharedGet is true when evictor is [sharedAccessEvictor].
+	sharedGet bool
+	// loads has its own lock, so slow loads don't block other operations.
+	loads loadGroup
+	// loader refreshes stale entries in the background, nil disables refresh.
+	loader    Loader
+	refreshes sync.WaitGroup
+	// absentTTL is the default TTL of known absent keys, see [WithAbsentTTL].
+	absentTTL time.Duration
+	// version is the last version assigned to an entry, it only increases.
+	version uint64
+	// tags is the reverse index from tag to entries in all the buckets.
+	tags map[string]map[*cacheEntry]struct{}
+
+	// bucketBytes is the total size of entries in each bucket, it is removed with the bucket.
+	bucketBytes map[string]int64
+	// keyIndexes keeps keys of scanned buckets in sorted order, it is built on
+	// the first Scan of the bucket and removed with the bucket.
+	keyIndexes map[string]*keyIndex
+	// bucketConfigs is kept even if the bucket is removed.
+	bucketConfigs       map[string]BucketConfig
+	defaultBucketConfig BucketConfig
 }
 
 type cacheEntry struct {
@@ -36,20 +68,84 @@ type cacheEntry struct {
 	key        string
 	value      []byte
 	expiration time.Time
+	// expireIndex is the position in [LRUCache] expirations, -1 if the entry has no TTL.
+	expireIndex int
+	// softExpiration is when the entry becomes stale, zero if there is no [Options.SoftTTL].
+	softExpiration time.Time
+	// opts is kept to refresh the entry using the same TTL.
+	opts Options
+	// loadTime is the latency of the load that set the value, it is used by XFetch.
+	loadTime time.Duration
+	// refreshing is true when a background refresh is in flight.
+	refreshing bool
+	// absent marks the key as known absent, value is nil.
+	absent bool
+	// version changes on every update, see [LRUCache.CompareAndSet].
+	version uint64
+	// modified is when the value was last changed, see [BucketStats].
+	modified time.Time
+
+	// Fields below are owned by the evictor.
+
+	// elem is the position of the entry in the evictor's list.
+	elem *list.Element
+	// freq is the frequency node of the entry in [lfuEvictor].
+	freq *list.Element
+	// segment is the list the entry belongs to e.g. window in [tinyLFUEvictor].
+	segment uint8
+	// visited is set on hit by [sieveEvictor] and [clockEvictor] without the write lock.
+	visited atomic.Bool
+}
+
+// entrySize is the bytes counted towards [WithMaxBytes].
+func entrySize(bucket string, key string, value []byte) int64 {
+	return int64(len(bucket) + len(key) + len(value))
+}
+
+func (e *cacheEntry) size() int64 {
+	return entrySize(e.bucket, e.key, e.value)
+}
+
+func (e *cacheEntry) expired(now time.Time) bool {
+	return !e.expiration.IsZero() && e.expiration.Before(now)
 }
 
+// NewLRUCache creates a cache holding at most capacity keys across all buckets.
+// Eviction policy is [EvictionPolicyLRU] unless changed by [WithEvictionPolicy].
+// Use [WithMaxBytes] to also limit the size of keys and values.
 func NewLRUCache(capacity int,
-	ttlCheckInterval time.Duration, metrics MetricsHandler) *LRUCache {
+	ttlCheckInterval time.Duration, metrics MetricsHandler, opts ...Option) (*LRUCache, error) {
+	cfg, err := applyOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	ev, err := newEvictor(cfg.evictionPolicy, capacity, metrics)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &LRUCache{
 		capacity:         capacity,
+		maxBytes:         cfg.maxBytes,
+		policy:           cfg.evictionPolicy,
 		ttlCheckInterval: ttlCheckInterval,
 		stop:             make(chan struct{}),
 		metrics:          metrics,
-		buckets:          make(map[string]map[string]*list.Element),
-		order:            list.New(),
+		clock:            cfg.clock,
+		buckets:          make(map[string]map[string]*cacheEntry),
+		evictor:          ev,
+		loader:           cfg.loader,
+		absentTTL:        cfg.absentTTL,
+
+		tags:                make(map[string]map[*cacheEntry]struct{}),
+		bucketBytes:         make(map[string]int64),
+		keyIndexes:          make(map[string]*keyIndex),
+		bucketConfigs:       make(map[string]BucketConfig),
+		defaultBucketConfig: cfg.defaultBucketConfig,
 	}
+	_, c.sharedGet = ev.(sharedAccessEvictor)
 	c.startTTLCheck()
-	return c
+	return c, nil
 }
 
 func (c *LRUCache) Set(bucket string, key string, value []byte, opts Options) error {
@@ -57,84 +153,237 @@ func (c *LRUCache) Set(bucket string, key string, value []byte, opts Options) er
 	defer c.mu.Unlock()
 	defer c.metrics.AddSet()
 
-	// Create bucket if not exists
-	b, ok := c.buckets[bucket]
-	if !ok {
-		b = make(map[string]*list.Element)
-		c.buckets[bucket] = b
+	_, err := c.set(bucket, key, value, opts)
+	return err
+}
+
+// set inserts or updates the entry and returns it.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) set(bucket string, key string, value []byte, opts Options) (*cacheEntry, error) {
+	size := entrySize(bucket, key, value)
+	if err := c.checkSize(bucket, key, size); err != nil {
+		return nil, err
 	}
 
-	// Create new entry
+	opts.Tags = normalizeTags(opts.Tags)
+	now := c.clock.Now()
 	expiration := time.Time{}
 	if opts.TTL > 0 {
-		expiration = time.Now().Add(opts.TTL)
+		expiration = now.Add(opts.TTL)
+	}
+	softExpiration := time.Time{}
+	if opts.SoftTTL > 0 {
+		softExpiration = now.Add(opts.SoftTTL)
 	}
-	entry := cacheEntry{bucket: bucket, key: key, value: value, expiration: expiration}
 
 	// Check if the key already exists
-	e, ok := b[key]
+	entry, ok := c.buckets[bucket][key]
 	if ok {
-		e.Value = entry
-		if opts.EvictionPolicy == EvictionPolicyLRU || opts.EvictionPolicy == EvictionPolicyMRU {
-			c.order.MoveToBack(e)
-		}
+		entry.absent = false
+		entry.softExpiration = softExpiration
+		c.untag(entry)
+		entry.opts = opts
+		c.tag(entry)
+		entry.loadTime = 0
+		entry.refreshing = false
+		c.setExpiration(entry, expiration)
+		c.update(entry, value)
 		c.metrics.AddSetExists()
-		return nil
+		return entry, nil
 	}
 
-	// Evict before inserting new key
-	size := c.order.Len()
-	if size >= c.capacity {
-		c.evict(opts.EvictionPolicy)
+	// Evict before inserting new key, this may remove empty bucket.
+	// Evict from the bucket first if it reaches its own limit.
+	c.makeBucketRoom(bucket, 1, size)
+	c.makeRoom(size)
+
+	// Create bucket if not exists
+	b, ok := c.buckets[bucket]
+	if !ok {
+		b = make(map[string]*cacheEntry)
+		c.buckets[bucket] = b
 	}
 
 	// Add new key to the bucket
-	e = c.order.PushBack(entry)
-	b[key] = e
+	c.version++
+	entry = &cacheEntry{bucket: bucket, key: key, value: value, expireIndex: -1,
+		softExpiration: softExpiration, opts: opts, version: c.version, modified: now}
+	c.setExpiration(entry, expiration)
+	c.tag(entry)
+	c.evictor.add(entry)
+	b[key] = entry
+	if idx := c.keyIndexes[bucket]; idx != nil {
+		idx.insert(key)
+	}
+	c.bytes += size
+	c.bucketBytes[bucket] += size
+
+	return entry, nil
+}
 
+// checkSize returns [ErrTooLarge] if the entry can never fit into the cache or its bucket.
+// NOTE: caller must hold the lock.
+func (c *LRUCache) checkSize(bucket string, key string, size int64) error {
+	bucketCfg := c.bucketConfig(bucket)
+	if (c.maxBytes > 0 && size > c.maxBytes) || (bucketCfg.MaxBytes > 0 && size > bucketCfg.MaxBytes) {
+		return keyError(bucket, key, ErrTooLarge)
+	}
 	return nil
 }
 
+// update replaces the value of an existing entry without changing its TTL,
+// other entries are evicted if the new value exceeds the limits.
+// NOTE: caller must hold the write lock and call checkSize.
+func (c *LRUCache) update(entry *cacheEntry, value []byte) {
+	delta := entrySize(entry.bucket, entry.key, value) - entry.size()
+	c.bytes += delta
+	c.bucketBytes[entry.bucket] += delta
+	entry.value = value
+	entry.modified = c.clock.Now()
+	c.version++
+	entry.version = c.version
+
+	bucketCfg := c.bucketConfig(entry.bucket)
+	if (c.maxBytes > 0 && c.bytes > c.maxBytes) ||
+		(bucketCfg.MaxBytes > 0 && c.bucketBytes[entry.bucket] > bucketCfg.MaxBytes) {
+		// Evict other entries, the updated entry is not tracked
+		// by evictor so it can't be the victim.
+		c.evictor.remove(entry)
+		c.makeBucketRoom(entry.bucket, 0, 0)
+		c.makeRoom(0)
+		c.evictor.add(entry)
+	} else {
+		c.evictor.access(entry)
+	}
+}
+
 func (c *LRUCache) Get(bucket string, key string, opts Options) ([]byte, error) {
+	value, _, err := c.GetWithVersion(bucket, key, opts)
+	return value, err
+}
+
+// GetWithVersion is [LRUCache.Get] that also returns the version of the value for
+// [LRUCache.CompareAndSet] and [LRUCache.CompareAndDelete].
+func (c *LRUCache) GetWithVersion(bucket string, key string, opts Options) ([]byte, uint64, error) {
+	if c.sharedGet {
+		if value, version, ok := c.getShared(bucket, key); ok {
+			return value, version, nil
+		}
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Per requirement, use Oldest eviction policy on Get.
-	// TODO: this requirement is quite confusing, why not use
-	// the policy provided in the options?
-	size := c.order.Len()
-	if size >= c.capacity {
-		c.evict(EvictionPolicyNone)
+	// Per requirement, evict on Get when capacity is reached.
+	// [sharedAccessEvictor] skips it, otherwise Get on a full cache, which is the
+	// steady state, always needs the write lock and the read lock path is useless.
+	if !c.sharedGet && c.evictor.len() >= c.capacity {
+		c.evict()
 	}
+	return c.get(bucket, key)
+}
 
-	// TODO: define error for not found
-	b, ok := c.buckets[bucket]
+// get is Get after eviction, caller must hold the write lock.
+func (c *LRUCache) get(bucket string, key string) ([]byte, uint64, error) {
+	entry, err := c.lookup(bucket, key)
+	if err != nil {
+		c.addNotFound(err)
+		return nil, 0, err
+	}
+	now := c.clock.Now()
+	// Stale value is still returned while it is refreshed
+	if c.shouldRefresh(entry, now) {
+		c.refresh(entry)
+	}
+	if entry.opts.Sliding && entry.opts.TTL > 0 {
+		c.setExpiration(entry, now.Add(entry.opts.TTL))
+	}
+
+	c.evictor.access(entry)
+
+	c.metrics.AddHit()
+	if entry.absent {
+		return nil, 0, keyError(bucket, key, ErrAbsent)
+	}
+	return entry.value, entry.version, nil
+}
+
+// getShared serves hit under the read lock when evictor is [sharedAccessEvictor].
+// It returns false when Get needs the write lock for miss, absent, expire, sliding TTL or refresh.
+func (c *LRUCache) getShared(bucket string, key string) ([]byte, uint64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.buckets[bucket][key]
 	if !ok {
+		return nil, 0, false
+	}
+	now := c.clock.Now()
+	if entry.absent || entry.opts.Sliding || entry.expired(now) || c.shouldRefresh(entry, now) {
+		return nil, 0, false
+	}
+
+	c.evictor.access(entry)
+	c.metrics.AddHit()
+	return entry.value, entry.version, true
+}
+
+// addNotFound counts error of lookup as miss, expired key is already counted by lookup.
+func (c *LRUCache) addNotFound(err error) {
+	if !errors.Is(err, ErrExpired) {
 		c.metrics.AddNotFound()
-		return nil, fmt.Errorf("bucket %s not found", bucket)
 	}
+}
 
-	e, ok := b[key]
+// lookup returns the entry if it exists and is not expired, expired entry is removed.
+// It does not update usage order and only reports expire metrics.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) lookup(bucket string, key string) (*cacheEntry, error) {
+	b, ok := c.buckets[bucket]
 	if !ok {
-		c.metrics.AddNotFound()
-		return nil, fmt.Errorf("key %s not found", key)
+		return nil, keyError(bucket, key, ErrBucketNotFound)
 	}
 
-	entry := e.Value.(cacheEntry)
-	// Lazy TTL
-	if !entry.expiration.IsZero() && entry.expiration.Before(time.Now()) {
-		c.del(e)
-		c.metrics.AddExpire(true)
-		return nil, fmt.Errorf("key %s expired", key)
+	entry, ok := b[key]
+	if !ok {
+		return nil, keyError(bucket, key, ErrNotFound)
 	}
 
-	// Update order for LRU and MRU
-	if opts.EvictionPolicy == EvictionPolicyLRU || opts.EvictionPolicy == EvictionPolicyMRU {
-		c.order.MoveToBack(e)
+	// Lazy TTL
+	if entry.expired(c.clock.Now()) {
+		c.del(entry)
+		c.metrics.AddExpire(true, entry.opts.Sliding)
+		return nil, keyError(bucket, key, ErrExpired)
 	}
+	return entry, nil
+}
 
-	c.metrics.AddHit()
-	return entry.value, nil
+// SetAbsent caches the key as known absent, Get returns [ErrAbsent] until it expires
+// or is replaced by Set. Default TTL is [WithAbsentTTL] when opts has no TTL,
+// or [DefaultAbsentTTL] if it is not set, so the marker never hides real data forever.
+func (c *LRUCache) SetAbsent(bucket string, key string, opts Options) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.metrics.AddSet()
+
+	return c.setAbsent(bucket, key, opts)
+}
+
+// setAbsent is shared by SetAbsent and load.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) setAbsent(bucket string, key string, opts Options) error {
+	if opts.TTL <= 0 {
+		opts.TTL = c.absentTTL
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultAbsentTTL
+	}
+	entry, err := c.set(bucket, key, nil, opts)
+	if err != nil {
+		return err
+	}
+	entry.absent = true
+	return nil
 }
 
 // Delete key from the cache, empty bucket is also removed.
@@ -144,17 +393,22 @@ func (c *LRUCache) Delete(bucket string, key string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	return c.delete(bucket, key)
+}
+
+// delete is Delete without metrics, caller must hold the write lock.
+func (c *LRUCache) delete(bucket string, key string) error {
 	// Error if not exists
 	b, ok := c.buckets[bucket]
 	if !ok {
 		c.metrics.AddNotFound()
-		return fmt.Errorf("bucket %s not found", bucket)
+		return keyError(bucket, key, ErrBucketNotFound)
 	}
 
 	_, ok = b[key]
 	if !ok {
 		c.metrics.AddNotFound()
-		return fmt.Errorf("key %s not found", key)
+		return keyError(bucket, key, ErrNotFound)
 	}
 
 	// Delete if exists
@@ -162,40 +416,61 @@ func (c *LRUCache) Delete(bucket string, key string) error {
 	return nil
 }
 
-// Stop the background TTL check (if any).
+func (c *LRUCache) EvictionPolicy() EvictionPolicy {
+	return c.policy
+}
+
+func (c *LRUCache) HasLoader() bool {
+	return c.loader != nil
+}
+
+// Stop the background TTL check (if any) and wait for refreshes in flight.
 // NOTE: Even if you stop the check in the background
 // [Get] still checks the TTL.
 func (c *LRUCache) Stop() {
 	close(c.stop)
+	c.refreshes.Wait()
+}
+
+// makeRoom evicts until there is room for a new entry of size bytes.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) makeRoom(size int64) {
+	for c.evictor.len() > 0 &&
+		(c.evictor.len() >= c.capacity || (c.maxBytes > 0 && c.bytes+size > c.maxBytes)) {
+		c.evict()
+	}
 }
 
 // Called by Set and Get when capacity is reached
-func (c *LRUCache) evict(policy EvictionPolicy) {
+func (c *LRUCache) evict() {
 	// No need to lock, caller already holds the lock
 
-	var e *list.Element
-	switch policy {
-	case EvictionPolicyMRU, EvictionPolicyNewest:
-		e = c.order.Back()
-	default:
-		// LRU, None, Oldest
-		e = c.order.Front()
+	e := c.evictor.victim()
+	if e == nil {
+		return
 	}
-
 	c.del(e)
 	c.metrics.AddEvict()
 }
 
 // Shared by evict and Delete.
 // NOTE: caller must hold the write lock.
-func (c *LRUCache) del(e *list.Element) {
-	c.order.Remove(e)
+func (c *LRUCache) del(entry *cacheEntry) {
+	c.evictor.remove(entry)
+	c.removeExpiration(entry)
+	c.untag(entry)
+	c.bytes -= entry.size()
+	c.bucketBytes[entry.bucket] -= entry.size()
 
-	entry := e.Value.(cacheEntry)
 	b := c.buckets[entry.bucket]
 	delete(b, entry.key)
+	if idx := c.keyIndexes[entry.bucket]; idx != nil {
+		idx.remove(entry.key)
+	}
 	if len(b) == 0 {
 		delete(c.buckets, entry.bucket)
+		delete(c.bucketBytes, entry.bucket)
+		delete(c.keyIndexes, entry.bucket)
 	}
 }
 
@@ -204,12 +479,11 @@ func (c *LRUCache) startTTLCheck() {
 		return
 	}
 
-	ticker := time.NewTicker(c.ttlCheckInterval)
+	ticker := c.clock.NewTicker(c.ttlCheckInterval)
 	go func() {
 		for {
 			select {
-			case <-ticker.C:
-				c.metrics.SetSize(c.order.Len())
+			case <-ticker.C():
 				c.checkExpired()
 			case <-c.stop:
 				ticker.Stop()
@@ -219,16 +493,23 @@ func (c *LRUCache) startTTLCheck() {
 	}()
 }
 
+// checkExpired removes expired entries from the top of the expiration heap.
 func (c *LRUCache) checkExpired() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, b := range c.buckets {
-		for _, e := range b {
-			entry := e.Value.(cacheEntry)
-			if !entry.expiration.IsZero() && entry.expiration.Before(time.Now()) {
-				c.del(e)
-			}
-		}
+	now := c.clock.Now()
+	for len(c.expirations) > 0 && c.expirations[0].expiration.Before(now) {
+		e := c.expirations[0]
+		c.del(e)
+		c.metrics.AddExpire(false, e.opts.Sliding)
 	}
+	c.reportSize()
+}
+
+// reportSize is called after removing many entries at once.
+// NOTE: caller must hold the lock.
+func (c *LRUCache) reportSize() {
+	c.metrics.SetSize(c.evictor.len())
+	c.metrics.SetBytes(c.bytes)
 }
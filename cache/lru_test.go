@@ -3,10 +3,14 @@ package cache
 import (
 	"container/list"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
 )
 
 // Test using container/list for tracking recent usage
@@ -23,19 +27,49 @@ func TestList(t *testing.T) {
 	fmt.Println(l.Front().Value)
 }
 
+func newTestCache(t *testing.T, capacity int, ttlCheckInterval time.Duration, opts ...Option) *LRUCache {
+	c, err := NewLRUCache(capacity, ttlCheckInterval, &noopMetrics{}, opts...)
+	require.NoError(t, err)
+	return c
+}
+
 func TestNoTTL(t *testing.T) {
-	c := NewLRUCache(10, 0, &noopMetrics{})
+	c := newTestCache(t, 10, 0)
 	c.Set("b1", "k1", []byte("v1"), Options{})
 	value, err := c.Get("b1", "k1", Options{})
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("v1"), value)
 
 	_, err = c.Get("b1", "k2", Options{})
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestErrors(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c := newTestCache(t, 10, 0, WithClock(clk))
+	_, err := c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	assert.True(t, IsMiss(err))
+
+	var keyErr *KeyError
+	assert.ErrorAs(t, err, &keyErr)
+	assert.Equal(t, "b1", keyErr.Bucket)
+	assert.Equal(t, "k1", keyErr.Key)
+
+	c.Set("b1", "k1", []byte("v1"), Options{TTL: time.Millisecond})
+	clk.Advance(2 * time.Millisecond)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrExpired)
+
+	err = c.Delete("b1", "k1")
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	c.Set("b1", "k1", []byte("v1"), Options{})
+	err = c.Delete("b1", "k2")
+	assert.ErrorIs(t, err, ErrNotFound)
 }
 
 func TestCapacity(t *testing.T) {
-	c := NewLRUCache(3, 0, &noopMetrics{})
+	c := newTestCache(t, 3, 0)
 	c.Set("b1", "k1", []byte("v1"), Options{})
 	c.Set("b1", "k2", []byte("v2"), Options{})
 	c.Set("b1", "k3", []byte("v3"), Options{})
@@ -48,24 +82,199 @@ func TestCapacity(t *testing.T) {
 	assert.Equal(t, []byte("v2"), v)
 }
 
+func TestMaxBytes(t *testing.T) {
+	c := newTestCache(t, 10, 0, WithMaxBytes(20))
+	// 2 + 2 + 10
+	assert.NoError(t, c.Set("b1", "k1", []byte("0123456789"), Options{}))
+	assert.Equal(t, int64(14), c.bytes)
+	// 2 + 2 + 5, evicts k1
+	assert.NoError(t, c.Set("b1", "k2", []byte("01234"), Options{}))
+	assert.Equal(t, int64(9), c.bytes)
+	_, err := c.Get("b1", "k1", Options{})
+	assert.Error(t, err)
+
+	err = c.Set("b1", "k3", []byte("012345678901234567"), Options{})
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	// Update grows the value, evicts other entries but not itself
+	assert.NoError(t, c.Set("b1", "k3", []byte("0"), Options{}))
+	assert.NoError(t, c.Set("b1", "k3", []byte("0123456789"), Options{}))
+	_, err = c.Get("b1", "k2", Options{})
+	assert.Error(t, err)
+	v, err := c.Get("b1", "k3", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("0123456789"), v)
+	assert.Equal(t, int64(14), c.bytes)
+
+	assert.NoError(t, c.Delete("b1", "k3"))
+	assert.Equal(t, int64(0), c.bytes)
+}
+
+func TestEvictEmptyBucket(t *testing.T) {
+	c := newTestCache(t, 1, 0)
+	c.Set("b1", "k1", []byte("v1"), Options{})
+	// Evicting k1 removes bucket b1, k2 should still be added
+	c.Set("b1", "k2", []byte("v2"), Options{})
+	assert.Len(t, c.buckets["b1"], 1)
+	assert.Equal(t, 1, c.evictor.len())
+}
+
 func TestTTL(t *testing.T) {
-	c := NewLRUCache(10, 20*time.Millisecond, &noopMetrics{})
+	clk := clocktest.NewFake(time.Now())
+	metrics := &expireMetrics{}
+	c, err := NewLRUCache(10, 20*time.Millisecond, metrics, WithClock(clk))
+	require.NoError(t, err)
+	defer c.Stop()
+
 	c.Set("b1", "k1", []byte("v1"), Options{
 		TTL: 300 * time.Millisecond,
 	})
+	c.Set("b1", "k2", []byte("v2"), Options{
+		TTL: 300 * time.Millisecond,
+	})
 
-	time.Sleep(100 * time.Millisecond)
+	clk.Advance(100 * time.Millisecond)
 
 	v, err := c.Get("b1", "k1", Options{})
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("v1"), v)
 
-	time.Sleep(300 * time.Millisecond)
+	// Fires multiple ticks, the background check for the first tick
+	// is done when the second tick is received.
+	clk.Advance(300 * time.Millisecond)
 
 	_, err = c.Get("b1", "k1", Options{})
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	assert.Equal(t, 2, metrics.active)
+	assert.Equal(t, 0, metrics.lazy)
+}
+
+func TestLazyTTL(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &expireMetrics{}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk))
+	require.NoError(t, err)
+
+	c.Set("b1", "k1", []byte("v1"), Options{TTL: time.Second})
+	clk.Advance(time.Second - time.Nanosecond)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.NoError(t, err)
+
+	clk.Advance(2 * time.Nanosecond)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.Equal(t, 1, metrics.lazy)
+}
+
+func TestEvictionPolicy(t *testing.T) {
+	tests := []struct {
+		policy  EvictionPolicy
+		evicted string
+	}{
+		{EvictionPolicyNone, "k1"},
+		{EvictionPolicyOldest, "k1"},
+		{EvictionPolicyNewest, "k3"},
+		{EvictionPolicyLRU, "k2"},
+		{EvictionPolicyMRU, "k1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			c := newTestCache(t, 3, 0, WithEvictionPolicy(tt.policy))
+			assert.Equal(t, tt.policy, c.EvictionPolicy())
+			c.Set("b1", "k1", []byte("v1"), Options{})
+			c.Set("b1", "k2", []byte("v2"), Options{})
+			c.Set("b1", "k3", []byte("v3"), Options{})
+			// Only changes order for LRU and MRU
+			c.Set("b1", "k1", []byte("v1"), Options{})
+			c.Set("b1", "k4", []byte("v4"), Options{})
 
-	c.Stop()
+			for _, k := range []string{"k1", "k2", "k3"} {
+				_, ok := c.buckets["b1"][k]
+				assert.Equal(t, k != tt.evicted, ok, k)
+			}
+		})
+	}
 }
 
-// TODO: Test different evict policies, mabye table test
+func TestScanResistance(t *testing.T) {
+	tests := []struct {
+		policy    EvictionPolicy
+		resistant bool
+	}{
+		{EvictionPolicyLRU, false},
+		{EvictionPolicyLFU, true},
+		{EvictionPolicyTinyLFU, true},
+		{EvictionPolicyARC, true},
+		{EvictionPolicy2Q, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			c := newTestCache(t, 100, 0, WithEvictionPolicy(tt.policy))
+			hot := 10
+			for i := 0; i < hot; i++ {
+				c.Set("b1", fmt.Sprintf("hot%d", i), []byte("v"), Options{})
+			}
+			for round := 0; round < 3; round++ {
+				for i := 0; i < hot; i++ {
+					_, err := c.Get("b1", fmt.Sprintf("hot%d", i), Options{})
+					require.NoError(t, err)
+				}
+			}
+			// Keys only used once should not flush the hot keys
+			for i := 0; i < 1000; i++ {
+				c.Set("b1", fmt.Sprintf("scan%d", i), []byte("v"), Options{})
+			}
+			kept := 0
+			for i := 0; i < hot; i++ {
+				if _, ok := c.buckets["b1"][fmt.Sprintf("hot%d", i)]; ok {
+					kept++
+				}
+			}
+			if tt.resistant {
+				assert.Equal(t, hot, kept)
+			} else {
+				assert.Less(t, kept, hot)
+			}
+			assert.LessOrEqual(t, c.evictor.len(), 100)
+		})
+	}
+}
+
+func TestParseEvictionPolicy(t *testing.T) {
+	for _, p := range []EvictionPolicy{EvictionPolicyNone, EvictionPolicyOldest, EvictionPolicyNewest, EvictionPolicyLRU, EvictionPolicyMRU, EvictionPolicyLFU, EvictionPolicyTinyLFU, EvictionPolicyARC, EvictionPolicy2Q,
+		EvictionPolicySIEVE, EvictionPolicyCLOCK} {
+		parsed, err := ParseEvictionPolicy(p.String())
+		assert.NoError(t, err)
+		assert.Equal(t, p, parsed)
+	}
+	_, err := ParseEvictionPolicy("foo")
+	assert.Error(t, err)
+}
+
+// BenchmarkParallelGet compares policies that need the write lock on Get (LRU)
+// with policies that only set a visited bit under the read lock (SIEVE and CLOCK).
+func BenchmarkParallelGet(b *testing.B) {
+	const keys = 1024
+	for _, policy := range []EvictionPolicy{EvictionPolicyLRU, EvictionPolicySIEVE, EvictionPolicyCLOCK} {
+		// Full cache is the steady state in production
+		for _, capacity := range []int{keys, 2 * keys} {
+			b.Run(fmt.Sprintf("%s/capacity=%d", policy, capacity), func(b *testing.B) {
+				c, err := NewLRUCache(capacity, 0, &noopMetrics{}, WithEvictionPolicy(policy))
+				require.NoError(b, err)
+				for i := 0; i < keys; i++ {
+					c.Set("b1", strconv.Itoa(i), []byte("v"), Options{})
+				}
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						if _, err := c.Get("b1", strconv.Itoa(i%keys), Options{}); err != nil {
+							b.Fatal(err)
+						}
+						i++
+					}
+				})
+			})
+		}
+	}
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -21,6 +22,11 @@ type MetricsHandler interface {
 	AddNotFound()
 	AddHit()
 
+	// Load
+
+	ObserveLoad(latency time.Duration)
+	AddLoadError()
+
 	// Set
 
 	AddSet()
@@ -30,11 +36,18 @@ type MetricsHandler interface {
 
 	AddDelete()
 	AddEvict()
-	AddExpire(lazy bool)
+	// AddExpire is called when the key is removed after TTL, lazy is true if it is found by Get
+	// instead of the background check, sliding is true if the TTL is [Options.Sliding].
+	AddExpire(lazy bool, sliding bool)
+	// AddReject is called when admission policy e.g. [EvictionPolicyTinyLFU]
+	// rejects a new key in favor of existing keys.
+	AddReject()
 
 	// Size
 
 	SetSize(size int)
+	// SetBytes reports total size of bucket, key and value of all the entries.
+	SetBytes(bytes int64)
 }
 
 // MetricsExporter allows http server to export metrics.
@@ -47,24 +60,32 @@ type MetricsExporter interface {
 
 type noopMetrics struct{}
 
-func (n *noopMetrics) AddNotFound()        {}
-func (n *noopMetrics) AddHit()             {}
-func (n *noopMetrics) AddSet()             {}
-func (n *noopMetrics) AddSetExists()       {}
-func (n *noopMetrics) AddDelete()          {}
-func (n *noopMetrics) AddEvict()           {}
-func (n *noopMetrics) AddExpire(lazy bool) {}
-func (n *noopMetrics) SetSize(size int)    {}
+func (n *noopMetrics) AddNotFound()                      {}
+func (n *noopMetrics) AddHit()                           {}
+func (n *noopMetrics) ObserveLoad(latency time.Duration) {}
+func (n *noopMetrics) AddLoadError()                     {}
+func (n *noopMetrics) AddSet()                           {}
+func (n *noopMetrics) AddSetExists()                     {}
+func (n *noopMetrics) AddDelete()                        {}
+func (n *noopMetrics) AddEvict()                         {}
+func (n *noopMetrics) AddExpire(lazy bool, sliding bool) {}
+func (n *noopMetrics) AddReject()                        {}
+func (n *noopMetrics) SetSize(size int)                  {}
+func (n *noopMetrics) SetBytes(bytes int64)              {}
 
 type prometheusMetrics struct {
 	notFound  *prometheus.CounterVec
 	hit       *prometheus.CounterVec
+	load      *prometheus.HistogramVec
+	loadError *prometheus.CounterVec
 	set       *prometheus.CounterVec
 	setExists *prometheus.CounterVec
 	delete    *prometheus.CounterVec
 	evict     *prometheus.CounterVec
 	expire    *prometheus.CounterVec
+	reject    *prometheus.CounterVec
 	size      *prometheus.GaugeVec
+	bytes     *prometheus.GaugeVec
 }
 
 // NewPrometheusMetrics creates a new prometheus metrics handler
@@ -83,6 +104,19 @@ func NewPrometheusMetrics() *prometheusMetrics {
 			Name:      "hit",
 			Help:      "Number of hit keys",
 		}, nil),
+		load: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "cache",
+			Subsystem: "lru",
+			Name:      "load_seconds",
+			Help:      "Latency of loading keys on miss in seconds",
+			Buckets:   prometheus.DefBuckets,
+		}, nil),
+		loadError: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "cache",
+			Subsystem: "lru",
+			Name:      "load_error",
+			Help:      "Number of failed loads",
+		}, nil),
 		set: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: "cache",
 			Subsystem: "lru",
@@ -112,16 +146,28 @@ func NewPrometheusMetrics() *prometheusMetrics {
 			Subsystem: "lru",
 			Name:      "expire",
 			Help:      "Number of expired keys",
-		}, []string{"lazy"}),
+		}, []string{"lazy", "sliding"}),
+		reject: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "cache",
+			Subsystem: "lru",
+			Name:      "reject",
+			Help:      "Number of keys rejected by admission policy",
+		}, nil),
 		size: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: "cache",
 			Subsystem: "lru",
 			Name:      "size",
 			Help:      "Number of keys in the cache",
 		}, nil),
+		bytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "cache",
+			Subsystem: "lru",
+			Name:      "bytes",
+			Help:      "Size of buckets, keys and values in the cache",
+		}, nil),
 	}
 
-	prometheus.MustRegister(p.notFound, p.hit, p.set, p.setExists, p.delete, p.evict, p.expire, p.size)
+	prometheus.MustRegister(p.notFound, p.hit, p.load, p.loadError, p.set, p.setExists, p.delete, p.evict, p.expire, p.reject, p.size, p.bytes)
 	return p
 }
 
@@ -137,6 +183,14 @@ func (m *prometheusMetrics) AddHit() {
 	m.hit.WithLabelValues().Inc()
 }
 
+func (m *prometheusMetrics) ObserveLoad(latency time.Duration) {
+	m.load.WithLabelValues().Observe(latency.Seconds())
+}
+
+func (m *prometheusMetrics) AddLoadError() {
+	m.loadError.WithLabelValues().Inc()
+}
+
 func (m *prometheusMetrics) AddSet() {
 	m.set.WithLabelValues().Inc()
 }
@@ -153,10 +207,18 @@ func (m *prometheusMetrics) AddEvict() {
 	m.evict.WithLabelValues().Inc()
 }
 
-func (m *prometheusMetrics) AddExpire(lazy bool) {
-	m.expire.WithLabelValues(strconv.FormatBool(lazy)).Inc()
+func (m *prometheusMetrics) AddExpire(lazy bool, sliding bool) {
+	m.expire.WithLabelValues(strconv.FormatBool(lazy), strconv.FormatBool(sliding)).Inc()
+}
+
+func (m *prometheusMetrics) AddReject() {
+	m.reject.WithLabelValues().Inc()
 }
 
 func (m *prometheusMetrics) SetSize(size int) {
 	m.size.WithLabelValues().Set(float64(size))
 }
+
+func (m *prometheusMetrics) SetBytes(bytes int64) {
+	m.bytes.WithLabelValues().Set(float64(bytes))
+}
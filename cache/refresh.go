@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// shouldRefresh returns true if the entry is stale and there is no refresh in flight.
+// Before [Options.SoftTTL], the entry is refreshed early with probability based on
+// [Options.Beta] and the latency of last load, see XFetch in
+// "Optimal Probabilistic Cache Stampede Prevention" https://www.vldb.org/pvldb/vol8/p886-vattani.pdf
+// NOTE: caller must hold the read or write lock.
+func (c *LRUCache) shouldRefresh(entry *cacheEntry, now time.Time) bool {
+	if c.loader == nil || entry.refreshing || entry.softExpiration.IsZero() {
+		return false
+	}
+	if !entry.softExpiration.After(now) {
+		return true
+	}
+	if entry.opts.Beta <= 0 || entry.loadTime <= 0 {
+		return false
+	}
+	// -log(rand) is exponentially distributed, slow loads start earlier.
+	early := time.Duration(float64(entry.loadTime) * entry.opts.Beta * -math.Log(rand.Float64()))
+	return !entry.softExpiration.After(now.Add(early))
+}
+
+// refresh loads the entry in the background using [WithLoader] and the same [Options].
+// Refresh is not collapsed with [LRUCache.GetOrLoad], the entry may expire during
+// the refresh and GetOrLoad should not wait for a value that won't be cached.
+// Error and panic in loader are only recorded in metrics and the stale value is served until TTL.
+// Loaded value is discarded if the key is set, deleted or expired during the refresh.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) refresh(entry *cacheEntry) {
+	entry.refreshing = true
+	bucket, key, opts, version := entry.bucket, entry.key, entry.opts, entry.version
+	c.refreshes.Add(1)
+	go func() {
+		defer c.refreshes.Done()
+		defer c.refreshDone(bucket, key, version)
+		defer func() {
+			// There is no caller to raise the panic, a plain Get should not crash the process.
+			if r := recover(); r != nil {
+				c.metrics.AddLoadError()
+			}
+		}()
+
+		// Error is ignored, the value may also be loaded but not cached e.g. too large.
+		_, _ = c.load(context.Background(), bucket, key, opts, c.loader, &version)
+	}()
+}
+
+// refreshDone allows retry on next Get if the entry is not replaced,
+// set clears the flag when it replaces the entry.
+func (c *LRUCache) refreshDone(bucket string, key string, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.buckets[bucket][key]; ok && e.version == version {
+		e.refreshing = false
+	}
+}
@@ -0,0 +1,241 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+func TestSoftTTL(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	var version atomic.Int32
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		return []byte{byte('0' + version.Add(1))}, nil
+	}
+	c, err := NewLRUCache(10, 0, &noopMetrics{}, WithClock(clk), WithLoader(loader))
+	require.NoError(t, err)
+	opts := Options{TTL: 10 * time.Second, SoftTTL: time.Second}
+
+	require.NoError(t, c.Set("b1", "k1", []byte("0"), opts))
+	clk.Advance(500 * time.Millisecond)
+	v, err := c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("0"), v)
+	c.refreshes.Wait()
+	assert.Equal(t, int32(0), version.Load(), "fresh entry is not refreshed")
+
+	// Stale value is returned while refreshing
+	clk.Advance(time.Second)
+	v, err = c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("0"), v)
+	c.refreshes.Wait()
+	v, err = c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), v)
+	assert.Equal(t, int32(1), version.Load())
+
+	// Refreshed with the same options
+	clk.Advance(1500 * time.Millisecond)
+	_, err = c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	c.refreshes.Wait()
+	v, err = c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), v)
+
+	// Expired after hard TTL even if there is a loader
+	clk.Advance(11 * time.Second)
+	_, err = c.Get("b1", "k1", Options{})
+	assert.ErrorIs(t, err, ErrExpired)
+}
+
+func TestSoftTTLError(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &loadMetrics{}
+	var calls atomic.Int32
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		calls.Add(1)
+		return nil, errors.New("db is down")
+	}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk), WithLoader(loader),
+		WithEvictionPolicy(EvictionPolicySIEVE))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{TTL: 10 * time.Second, SoftTTL: time.Second}))
+	clk.Advance(2 * time.Second)
+	for range 2 {
+		v, err := c.Get("b1", "k1", Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), v, "stale value is served when refresh fails")
+		c.refreshes.Wait()
+	}
+	assert.Equal(t, int32(2), calls.Load(), "failed refresh is retried on next get")
+	assert.Equal(t, 2, metrics.loadErrors)
+}
+
+func TestSoftTTLPanic(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	metrics := &loadMetrics{}
+	var calls atomic.Int32
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		calls.Add(1)
+		panic("boom")
+	}
+	c, err := NewLRUCache(10, 0, metrics, WithClock(clk), WithLoader(loader))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{TTL: 10 * time.Second, SoftTTL: time.Second}))
+	clk.Advance(2 * time.Second)
+	for range 2 {
+		v, err := c.Get("b1", "k1", Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), v, "stale value is served when refresh panics")
+		c.refreshes.Wait()
+	}
+	assert.Equal(t, int32(2), calls.Load(), "panicked refresh is retried on next get")
+	assert.Equal(t, 2, metrics.loadErrors)
+}
+
+func TestXFetch(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	var calls atomic.Int32
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		calls.Add(1)
+		clk.Advance(100 * time.Millisecond)
+		return []byte("v"), nil
+	}
+	c, err := NewLRUCache(10, 0, &noopMetrics{}, WithClock(clk), WithLoader(loader))
+	require.NoError(t, err)
+
+	// Without beta, refresh only starts after soft TTL
+	_, err = c.GetOrLoad(context.Background(), "b1", "k1", Options{SoftTTL: time.Minute}, loader)
+	require.NoError(t, err)
+	for range 10 {
+		_, err = c.Get("b1", "k1", Options{})
+		require.NoError(t, err)
+	}
+	c.refreshes.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Large beta makes early refresh almost certain for a slow load
+	_, err = c.GetOrLoad(context.Background(), "b1", "k2", Options{SoftTTL: time.Minute, Beta: 1e9}, loader)
+	require.NoError(t, err)
+	_, err = c.Get("b1", "k2", Options{})
+	require.NoError(t, err)
+	c.refreshes.Wait()
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRefreshRace(t *testing.T) {
+	for _, write := range []string{"set", "delete"} {
+		t.Run(write, func(t *testing.T) {
+			clk := clocktest.NewFake(time.Now())
+			started := make(chan struct{})
+			release := make(chan struct{})
+			loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+				close(started)
+				<-release
+				return []byte("stale-db"), nil
+			}
+			c, err := NewLRUCache(10, 0, &noopMetrics{}, WithClock(clk), WithLoader(loader))
+			require.NoError(t, err)
+
+			require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{TTL: 10 * time.Second, SoftTTL: time.Second}))
+			clk.Advance(2 * time.Second)
+			_, err = c.Get("b1", "k1", Options{})
+			require.NoError(t, err)
+			<-started
+
+			// Write during the refresh wins over the loaded value
+			if write == "set" {
+				require.NoError(t, c.Set("b1", "k1", []byte("NEW"), Options{}))
+			} else {
+				require.NoError(t, c.Delete("b1", "k1"))
+			}
+			close(release)
+			c.refreshes.Wait()
+
+			v, err := c.Get("b1", "k1", Options{})
+			if write == "set" {
+				require.NoError(t, err)
+				assert.Equal(t, []byte("NEW"), v)
+			} else {
+				assert.True(t, IsMiss(err))
+			}
+		})
+	}
+}
+
+func TestRefreshExpired(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	refreshLoader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		close(started)
+		<-release
+		return []byte("refreshed"), nil
+	}
+	c, err := NewLRUCache(10, 0, &noopMetrics{}, WithClock(clk), WithLoader(refreshLoader))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{TTL: 10 * time.Second, SoftTTL: time.Second}))
+	clk.Advance(2 * time.Second)
+	_, err = c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	<-started
+
+	// Read through after the entry expires does not wait for the refresh
+	clk.Advance(10 * time.Second)
+	v, err := c.GetOrLoad(context.Background(), "b1", "k1", Options{}, func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		return []byte("loaded"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("loaded"), v)
+	close(release)
+	c.refreshes.Wait()
+
+	v, err = c.Get("b1", "k1", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("loaded"), v, "refresh does not overwrite the newer value")
+}
+
+func TestRefreshTooLarge(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	var calls atomic.Int32
+	loader := func(ctx context.Context, bucket string, key string) ([]byte, error) {
+		calls.Add(1)
+		return make([]byte, 100), nil
+	}
+	c, err := NewLRUCache(10, 0, &noopMetrics{}, WithClock(clk), WithLoader(loader), WithMaxBytes(50))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("b1", "k1", []byte("v1"), Options{TTL: 10 * time.Second, SoftTTL: time.Second}))
+	clk.Advance(2 * time.Second)
+	for range 2 {
+		v, err := c.Get("b1", "k1", Options{})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), v)
+		c.refreshes.Wait()
+	}
+	assert.Equal(t, int32(2), calls.Load(), "refresh is retried when value can't be cached")
+}
+
+func TestParseSoftTTL(t *testing.T) {
+	opts, err := ParseFromRequest(httptest.NewRequest("PUT", "/cache/b1/k1?ttl=1m&soft_ttl=5s&beta=1.5", nil))
+	require.NoError(t, err)
+	assert.Equal(t, Options{TTL: time.Minute, SoftTTL: 5 * time.Second, Beta: 1.5}, opts)
+
+	for _, query := range []string{"ttl=1s&soft_ttl=5s", "soft_ttl=-1s", "beta=-1", "beta=NaN", "beta=x"} {
+		_, err := ParseFromRequest(httptest.NewRequest("PUT", "/cache/b1/k1?"+query, nil))
+		assert.Error(t, err, query)
+	}
+}
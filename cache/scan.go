@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// defaultScanCount is used when count passed to Scan is not positive.
+const defaultScanCount = 10
+
+// Scan returns up to count keys in the bucket after cursor in key order, and the cursor
+// for next page, empty cursor means start or the end. match is a glob pattern where
+// * matches any characters and ? matches one character, use prefix* for prefix and
+// empty match for all keys. Expired and known absent keys are skipped.
+//
+// Each page only holds the read lock while walking keys after the cursor in the sorted
+// key index of the bucket, the index is built under the write lock on the first Scan.
+// Cursor is the last key returned, so keys that exist during the entire scan are
+// returned exactly once, keys added or removed during the scan may or may not be returned.
+func (c *LRUCache) Scan(bucket string, cursor string, match string, count int) ([]string, string, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if count <= 0 {
+		count = defaultScanCount
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	idx := c.keyIndexes[bucket]
+	if idx == nil {
+		c.mu.RUnlock()
+		c.buildKeyIndex(bucket)
+		c.mu.RLock()
+		// Bucket may be removed before getting the read lock again
+		if idx = c.keyIndexes[bucket]; idx == nil {
+			return nil, "", nil
+		}
+	}
+
+	now := c.clock.Now()
+	b := c.buckets[bucket]
+	n := idx.first()
+	if cursor != "" {
+		n = idx.after(after)
+	}
+	var keys []string
+	for ; n != nil; n = n.next[0] {
+		if e := b[n.key]; e.absent || e.expired(now) || !matchGlob(match, n.key) {
+			continue
+		}
+		// Stop at the first key of next page, so the last page has empty cursor
+		if len(keys) == count {
+			return keys, nextCursor(keys, true), nil
+		}
+		keys = append(keys, n.key)
+	}
+	return keys, "", nil
+}
+
+// buildKeyIndex sorts existing keys of the bucket, they are kept sorted by set and del afterwards.
+func (c *LRUCache) buildKeyIndex(bucket string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.buckets[bucket]
+	if !ok || c.keyIndexes[bucket] != nil {
+		return
+	}
+	idx := newKeyIndex()
+	for key := range b {
+		idx.insert(key)
+	}
+	c.keyIndexes[bucket] = idx
+}
+
+// DeleteByPrefix deletes all the keys starting with prefix in the bucket
+// and returns the number of deleted keys.
+func (c *LRUCache) DeleteByPrefix(bucket string, prefix string) int {
+	return c.deleteMatching(bucket, func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// DeleteByPattern deletes all the keys matching the glob pattern used by [LRUCache.Scan]
+// and returns the number of deleted keys.
+func (c *LRUCache) DeleteByPattern(bucket string, pattern string) int {
+	return c.deleteMatching(bucket, func(key string) bool {
+		return matchGlob(pattern, key)
+	})
+}
+
+// deleteMatching walks the bucket once under the write lock,
+// expired and known absent keys are also deleted and counted.
+func (c *LRUCache) deleteMatching(bucket string, match func(key string) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for key, e := range c.buckets[bucket] {
+		if match(key) {
+			c.del(e)
+			c.metrics.AddDelete()
+			n++
+		}
+	}
+	if n > 0 {
+		c.reportSize()
+	}
+	return n
+}
+
+// nextCursor returns empty cursor if there are no more keys.
+func nextCursor(keys []string, more bool) string {
+	if !more || len(keys) == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(keys[len(keys)-1]))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+	}
+	return string(b), nil
+}
+
+// matchGlob matches the entire key, * matches any characters including none
+// and ? matches exactly one character. Empty pattern matches all keys.
+func matchGlob(pattern string, key string) bool {
+	if pattern == "" {
+		return true
+	}
+	// Backtrack to the last * on mismatch.
+	p, k := 0, 0
+	star, starK := -1, 0
+	for k < len(key) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == key[k]):
+			p++
+			k++
+		case p < len(pattern) && pattern[p] == '*':
+			star, starK = p, k
+			p++
+		case star >= 0:
+			starK++
+			p, k = star+1, starK
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/at15/tinycache/cache/clock/clocktest"
+)
+
+// scanAll follows the cursor until the end.
+func scanAll(t *testing.T, c Cache, bucket string, match string, count int) []string {
+	var all []string
+	cursor := ""
+	for {
+		keys, next, err := c.Scan(bucket, cursor, match, count)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(keys), count)
+		all = append(all, keys...)
+		if next == "" {
+			return all
+		}
+		cursor = next
+	}
+}
+
+func TestScan(t *testing.T) {
+	clk := clocktest.NewFake(time.Now())
+	c := newTestCache(t, 100, 0, WithClock(clk))
+
+	var want []string
+	for i := 0; i < 25; i++ {
+		key := fmt.Sprintf("user:%02d", i)
+		want = append(want, key)
+		require.NoError(t, c.Set("b1", key, []byte("v"), Options{}))
+	}
+	require.NoError(t, c.Set("b1", "order:1", []byte("v"), Options{}))
+	require.NoError(t, c.Set("b1", "user:99", []byte("v"), Options{TTL: time.Second}))
+	require.NoError(t, c.SetAbsent("b1", "user:98", Options{}))
+	clk.Advance(2 * time.Second)
+
+	assert.Equal(t, want, scanAll(t, c, "b1", "user:*", 10))
+	assert.Equal(t, want, scanAll(t, c, "b1", "user:*", 25), "last page ends without extra call")
+	assert.Equal(t, []string{"order:1"}, scanAll(t, c, "b1", "*:?", 10))
+	assert.Len(t, scanAll(t, c, "b1", "", 7), 26)
+	assert.Empty(t, scanAll(t, c, "b2", "", 10))
+
+	// Keys that exist during the scan are returned once
+	keys, cursor, err := c.Scan("b1", "", "user:*", 10)
+	require.NoError(t, err)
+	assert.Equal(t, want[:10], keys)
+	require.NoError(t, c.Delete("b1", "user:05"))
+	require.NoError(t, c.Delete("b1", "user:15"))
+	require.NoError(t, c.Set("b1", "user:00a", []byte("v"), Options{}))
+	keys, _, err = c.Scan("b1", cursor, "user:*", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:10", "user:11", "user:12", "user:13", "user:14",
+		"user:16", "user:17", "user:18", "user:19", "user:20"}, keys)
+
+	_, _, err = c.Scan("b1", "not base64!", "", 10)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		match   bool
+	}{
+		{"", "anything", true},
+		{"*", "", true},
+		{"user:*", "user:1", true},
+		{"user:*", "user", false},
+		{"*:1", "user:1", true},
+		{"u?er", "user", true},
+		{"u?er", "uer", false},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+		{"a*a", "aaa", true},
+		{"*/*", "a/b", true},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.match, matchGlob(tc.pattern, tc.key), "%s %s", tc.pattern, tc.key)
+	}
+}
+
+type deleteMetrics struct {
+	noopMetrics
+	deletes int
+}
+
+func (m *deleteMetrics) AddDelete() {
+	m.deletes++
+}
+
+func TestDeleteByPattern(t *testing.T) {
+	metrics := &deleteMetrics{}
+	c, err := NewLRUCache(100, 0, metrics)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, c.Set("b1", fmt.Sprintf("user:1:%d", i), []byte("v"), Options{}))
+		require.NoError(t, c.Set("b1", fmt.Sprintf("user:2:%d", i), []byte("v"), Options{}))
+		require.NoError(t, c.Set("b2", fmt.Sprintf("user:1:%d", i), []byte("v"), Options{}))
+	}
+
+	assert.Equal(t, 5, c.DeleteByPrefix("b1", "user:1:"))
+	assert.Equal(t, 5, metrics.deletes)
+	assert.Equal(t, 0, c.DeleteByPrefix("b1", "user:1:"))
+	assert.Len(t, c.buckets["b2"], 5, "other bucket is not changed")
+
+	assert.Equal(t, 1, c.DeleteByPattern("b1", "user:?:4"))
+	assert.Equal(t, 0, c.DeleteByPattern("b1", "user:2:[0]"), "[ is not special")
+	assert.Equal(t, 4, c.DeleteByPattern("b1", "user:2:*"))
+	assert.Equal(t, 10, metrics.deletes)
+	assert.Equal(t, []string{"b2"}, c.ListBuckets())
+	assert.Equal(t, 0, c.DeleteByPattern("b3", "*"))
+}
+
+func TestScanKeyIndex(t *testing.T) {
+	c := newTestCache(t, 100, 0)
+	for _, key := range []string{"c", "a", "b"} {
+		require.NoError(t, c.Set("b1", key, []byte("v"), Options{}))
+	}
+	assert.Nil(t, c.keyIndexes["b1"], "index is only built by scan")
+	assert.Equal(t, []string{"a", "b", "c"}, scanAll(t, c, "b1", "", 2))
+	require.NotNil(t, c.keyIndexes["b1"])
+
+	// Index is kept sorted after the first scan
+	require.NoError(t, c.Set("b1", "bb", []byte("v"), Options{}))
+	require.NoError(t, c.Delete("b1", "a"))
+	assert.Equal(t, []string{"b", "bb", "c"}, indexKeys(c.keyIndexes["b1"]))
+	assert.Equal(t, []string{"b", "bb", "c"}, scanAll(t, c, "b1", "", 2))
+
+	// Index is removed with the bucket
+	_, err := c.FlushBucket("b1")
+	require.NoError(t, err)
+	assert.Empty(t, c.keyIndexes)
+	require.NoError(t, c.Set("b1", "d", []byte("v"), Options{}))
+	assert.Equal(t, []string{"d"}, scanAll(t, c, "b1", "", 2))
+}
+
+func BenchmarkScan(b *testing.B) {
+	c, err := NewLRUCache(100000, 0, &noopMetrics{})
+	require.NoError(b, err)
+	for i := range 100000 {
+		require.NoError(b, c.Set("b1", fmt.Sprintf("key:%06d", i), []byte("v"), Options{}))
+	}
+	b.ResetTimer()
+	for range b.N {
+		cursor := ""
+		for {
+			_, next, err := c.Scan("b1", cursor, "", 1000)
+			require.NoError(b, err)
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+	}
+}
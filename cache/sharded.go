@@ -0,0 +1,336 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"hash/maphash"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ Cache = &ShardedCache{}
+
+// ShardedCache implements [Cache] by splitting keys into independent [LRUCache] shards
+// based on hash of bucket and key. Each shard has its own lock, evictor, capacity
+// and TTL check, so operations on different shards don't block each other.
+// NOTE: Eviction is per shard, a key can be evicted before the entire cache is full.
+type ShardedCache struct {
+	seed    maphash.Seed
+	shards  []*LRUCache
+	metrics MetricsHandler
+	// sizes and bytes are reported by each shard, metrics get the sum.
+	sizes []atomic.Int64
+	bytes []atomic.Int64
+
+	// mu only protects bucketConfigs, shards have their own lock.
+	mu sync.RWMutex
+	// bucketConfigs keeps the config before splitting to shards.
+	bucketConfigs       map[string]BucketConfig
+	defaultBucketConfig BucketConfig
+}
+
+// NewShardedCache creates shardCount [LRUCache] that share the capacity and [WithMaxBytes],
+// all the shards use the same options and report to the same metrics.
+// Limits in [BucketConfig] are also split, so they are approximate.
+// NOTE: Value larger than the max bytes of a shard is rejected with [ErrTooLarge].
+func NewShardedCache(shardCount int, capacity int,
+	ttlCheckInterval time.Duration, metrics MetricsHandler, opts ...Option) (*ShardedCache, error) {
+	if shardCount <= 0 {
+		return nil, fmt.Errorf("shard count must be positive: %d", shardCount)
+	}
+	if capacity < shardCount {
+		return nil, fmt.Errorf("capacity %d is less than shard count %d", capacity, shardCount)
+	}
+	cfg, err := applyOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ShardedCache{
+		seed:    maphash.MakeSeed(),
+		shards:  make([]*LRUCache, shardCount),
+		metrics: metrics,
+		sizes:   make([]atomic.Int64, shardCount),
+		bytes:   make([]atomic.Int64, shardCount),
+
+		bucketConfigs:       make(map[string]BucketConfig),
+		defaultBucketConfig: cfg.defaultBucketConfig,
+	}
+	for i := range c.shards {
+		// Spread the remainder to the first few shards
+		shardOpts := append(opts[:len(opts):len(opts)],
+			WithMaxBytes(share(cfg.maxBytes, shardCount, i)),
+			WithDefaultBucketConfig(shareBucketConfig(cfg.defaultBucketConfig, shardCount, i)))
+		shard, err := NewLRUCache(int(share(int64(capacity), shardCount, i)), ttlCheckInterval,
+			&shardMetrics{MetricsHandler: metrics, cache: c, index: i}, shardOpts...)
+		if err != nil {
+			c.Stop()
+			return nil, err
+		}
+		c.shards[i] = shard
+	}
+	return c, nil
+}
+
+func (c *ShardedCache) Set(bucket string, key string, value []byte, opts Options) error {
+	return c.shard(bucket, key).Set(bucket, key, value, opts)
+}
+
+func (c *ShardedCache) Get(bucket string, key string, opts Options) ([]byte, error) {
+	return c.shard(bucket, key).Get(bucket, key, opts)
+}
+
+// GetWithVersion returns version from the shard, each shard has its own counter
+// so versions are only comparable for the same key.
+func (c *ShardedCache) GetWithVersion(bucket string, key string, opts Options) ([]byte, uint64, error) {
+	return c.shard(bucket, key).GetWithVersion(bucket, key, opts)
+}
+
+func (c *ShardedCache) CompareAndSet(bucket string, key string, value []byte, version uint64, opts Options) (uint64, error) {
+	return c.shard(bucket, key).CompareAndSet(bucket, key, value, version, opts)
+}
+
+func (c *ShardedCache) CompareAndDelete(bucket string, key string, version uint64) error {
+	return c.shard(bucket, key).CompareAndDelete(bucket, key, version)
+}
+
+func (c *ShardedCache) SetNX(bucket string, key string, value []byte, opts Options) (bool, error) {
+	return c.shard(bucket, key).SetNX(bucket, key, value, opts)
+}
+
+func (c *ShardedCache) SetXX(bucket string, key string, value []byte, opts Options) (bool, error) {
+	return c.shard(bucket, key).SetXX(bucket, key, value, opts)
+}
+
+func (c *ShardedCache) GetSet(bucket string, key string, value []byte, opts Options) ([]byte, bool, error) {
+	return c.shard(bucket, key).GetSet(bucket, key, value, opts)
+}
+
+func (c *ShardedCache) Increment(bucket string, key string, delta int64, initial int64, opts Options) (int64, error) {
+	return c.shard(bucket, key).Increment(bucket, key, delta, initial, opts)
+}
+
+func (c *ShardedCache) TTL(bucket string, key string) (time.Duration, error) {
+	return c.shard(bucket, key).TTL(bucket, key)
+}
+
+func (c *ShardedCache) Touch(bucket string, key string, ttl time.Duration) error {
+	return c.shard(bucket, key).Touch(bucket, key, ttl)
+}
+
+func (c *ShardedCache) Persist(bucket string, key string) error {
+	return c.shard(bucket, key).Persist(bucket, key)
+}
+
+func (c *ShardedCache) GetOrLoad(ctx context.Context, bucket string, key string, opts Options, loader Loader) ([]byte, error) {
+	return c.shard(bucket, key).GetOrLoad(ctx, bucket, key, opts, loader)
+}
+
+func (c *ShardedCache) SetAbsent(bucket string, key string, opts Options) error {
+	return c.shard(bucket, key).SetAbsent(bucket, key, opts)
+}
+
+func (c *ShardedCache) Delete(bucket string, key string) error {
+	return c.shard(bucket, key).Delete(bucket, key)
+}
+
+// ConfigureBucket splits the limits to all the shards.
+func (c *ShardedCache) ConfigureBucket(bucket string, cfg BucketConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.bucketConfigs[bucket] = cfg
+	for i, shard := range c.shards {
+		if err := shard.ConfigureBucket(bucket, shareBucketConfig(cfg, len(c.shards), i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BucketConfig returns the config before splitting to shards.
+func (c *ShardedCache) BucketConfig(bucket string) BucketConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if cfg, ok := c.bucketConfigs[bucket]; ok {
+		return cfg
+	}
+	return c.defaultBucketConfig
+}
+
+// ListBuckets merges buckets from all the shards.
+func (c *ShardedCache) ListBuckets() []string {
+	var buckets []string
+	for _, shard := range c.shards {
+		buckets = append(buckets, shard.ListBuckets()...)
+	}
+	slices.Sort(buckets)
+	return slices.Compact(buckets)
+}
+
+// BucketStats merges stats of the bucket from all the shards.
+func (c *ShardedCache) BucketStats(bucket string) (BucketStats, error) {
+	var stats BucketStats
+	for _, shard := range c.shards {
+		s, err := shard.BucketStats(bucket)
+		if err != nil {
+			continue
+		}
+		stats = stats.merge(s)
+	}
+	if stats.Entries == 0 {
+		return stats, fmt.Errorf("bucket %s: %w", bucket, ErrBucketNotFound)
+	}
+	return stats, nil
+}
+
+// Scan merges pages from all the shards, cursor is the last key so it works for all the shards.
+func (c *ShardedCache) Scan(bucket string, cursor string, match string, count int) ([]string, string, error) {
+	if count <= 0 {
+		count = defaultScanCount
+	}
+	var keys []string
+	more := false
+	for _, shard := range c.shards {
+		shardKeys, next, err := shard.Scan(bucket, cursor, match, count)
+		if err != nil {
+			return nil, "", err
+		}
+		keys = append(keys, shardKeys...)
+		more = more || next != ""
+	}
+	slices.Sort(keys)
+	if len(keys) > count {
+		keys = keys[:count]
+		more = true
+	}
+	return keys, nextCursor(keys, more), nil
+}
+
+func (c *ShardedCache) DeleteByPrefix(bucket string, prefix string) int {
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.DeleteByPrefix(bucket, prefix)
+	}
+	return total
+}
+
+func (c *ShardedCache) DeleteByPattern(bucket string, pattern string) int {
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.DeleteByPattern(bucket, pattern)
+	}
+	return total
+}
+
+func (c *ShardedCache) InvalidateTag(tag string) int {
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.InvalidateTag(tag)
+	}
+	return total
+}
+
+// FlushBucket flushes the bucket in all the shards one by one,
+// keys set during the flush may be kept.
+func (c *ShardedCache) FlushBucket(bucket string) (int, error) {
+	total := 0
+	for _, shard := range c.shards {
+		n, _ := shard.FlushBucket(bucket)
+		total += n
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("bucket %s: %w", bucket, ErrBucketNotFound)
+	}
+	return total, nil
+}
+
+// Flush flushes all the shards one by one.
+func (c *ShardedCache) Flush() int {
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.Flush()
+	}
+	return total
+}
+
+func (c *ShardedCache) EvictionPolicy() EvictionPolicy {
+	return c.shards[0].EvictionPolicy()
+}
+
+func (c *ShardedCache) HasLoader() bool {
+	return c.shards[0].HasLoader()
+}
+
+// Stop the background TTL check of all the shards.
+func (c *ShardedCache) Stop() {
+	for _, shard := range c.shards {
+		if shard != nil {
+			shard.Stop()
+		}
+	}
+}
+
+// share splits total among shards, the remainder goes to the first few shards.
+func share(total int64, shardCount int, index int) int64 {
+	n := total / int64(shardCount)
+	if int64(index) < total%int64(shardCount) {
+		n++
+	}
+	return n
+}
+
+// shareBucketConfig splits the limits, each shard gets at least 1
+// because 0 means no limit.
+func shareBucketConfig(cfg BucketConfig, shardCount int, index int) BucketConfig {
+	var shardCfg BucketConfig
+	if cfg.MaxEntries > 0 {
+		shardCfg.MaxEntries = max(1, int(share(int64(cfg.MaxEntries), shardCount, index)))
+	}
+	if cfg.MaxBytes > 0 {
+		shardCfg.MaxBytes = max(1, share(cfg.MaxBytes, shardCount, index))
+	}
+	return shardCfg
+}
+
+func (c *ShardedCache) shard(bucket string, key string) *LRUCache {
+	var h maphash.Hash
+	h.SetSeed(c.seed)
+	h.WriteString(bucket)
+	h.WriteByte(0)
+	h.WriteString(key)
+	return c.shards[h.Sum64()%uint64(len(c.shards))]
+}
+
+// shardMetrics reports size and bytes of the entire [ShardedCache] instead of a single shard,
+// other metrics are counters that can be reported directly.
+type shardMetrics struct {
+	MetricsHandler
+	cache *ShardedCache
+	index int
+}
+
+func (m *shardMetrics) SetSize(size int) {
+	m.cache.sizes[m.index].Store(int64(size))
+	total := 0
+	for i := range m.cache.sizes {
+		total += int(m.cache.sizes[i].Load())
+	}
+	m.MetricsHandler.SetSize(total)
+}
+
+func (m *shardMetrics) SetBytes(bytes int64) {
+	m.cache.bytes[m.index].Store(bytes)
+	var total int64
+	for i := range m.cache.bytes {
+		total += m.cache.bytes[i].Load()
+	}
+	m.MetricsHandler.SetBytes(total)
+}
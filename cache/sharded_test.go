@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sizeMetrics struct {
+	noopMetrics
+	size int
+}
+
+func (m *sizeMetrics) SetSize(size int) {
+	m.size = size
+}
+
+func TestShardedCache(t *testing.T) {
+	c, err := NewShardedCache(4, 102, 0, &noopMetrics{})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	// Remainder goes to first shards
+	capacities := make([]int, 0, 4)
+	for _, shard := range c.shards {
+		capacities = append(capacities, shard.capacity)
+	}
+	assert.Equal(t, []int{26, 26, 25, 25}, capacities)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, c.Set("b1", fmt.Sprintf("k%d", i), []byte("v"), Options{}))
+	}
+	v, err := c.Get("b1", "k1", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v"), v)
+
+	assert.NoError(t, c.Delete("b1", "k1"))
+	_, err = c.Get("b1", "k1", Options{})
+	assert.True(t, IsMiss(err))
+
+	c, err = NewShardedCache(4, 100, 0, &noopMetrics{}, WithMaxBytes(1002))
+	require.NoError(t, err)
+	assert.Equal(t, int64(251), c.shards[1].maxBytes)
+	assert.Equal(t, int64(250), c.shards[2].maxBytes)
+	c.Stop()
+
+	_, err = NewShardedCache(4, 2, 0, &noopMetrics{})
+	assert.Error(t, err)
+	_, err = NewShardedCache(2, 10, 0, &noopMetrics{}, WithEvictionPolicy(EvictionPolicy(100)))
+	assert.Error(t, err)
+}
+
+func TestShardedCacheBuckets(t *testing.T) {
+	c, err := NewShardedCache(4, 100, 0, &noopMetrics{})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, c.Set("b1", fmt.Sprintf("k%d", i), []byte("v"), Options{}))
+	}
+	require.NoError(t, c.Set("b2", "k1", []byte("v"), Options{}))
+	assert.Equal(t, []string{"b1", "b2"}, c.ListBuckets())
+
+	stats, err := c.BucketStats("b1")
+	require.NoError(t, err)
+	assert.Equal(t, 20, stats.Entries)
+	assert.Equal(t, int64(10*5+10*6), stats.Bytes)
+	_, err = c.BucketStats("b3")
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+
+	keys := scanAll(t, c, "b1", "k1*", 3)
+	assert.Equal(t, []string{"k1", "k10", "k11", "k12", "k13", "k14", "k15", "k16", "k17", "k18", "k19"}, keys)
+
+	n, err := c.FlushBucket("b1")
+	require.NoError(t, err)
+	assert.Equal(t, 20, n)
+	_, err = c.FlushBucket("b1")
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	assert.Equal(t, 1, c.Flush())
+	assert.Empty(t, c.ListBuckets())
+}
+
+func TestShardedCacheMetrics(t *testing.T) {
+	metrics := &sizeMetrics{}
+	c, err := NewShardedCache(4, 100, 0, metrics)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, c.Set("b1", fmt.Sprintf("k%d", i), []byte("v"), Options{}))
+	}
+	// Each shard reports its own size in TTL check
+	for _, shard := range c.shards {
+		shard.checkExpired()
+	}
+	assert.Equal(t, 20, metrics.size)
+}
+
+func TestShardedCacheConcurrent(t *testing.T) {
+	c, err := NewShardedCache(8, 1000, 0, &noopMetrics{})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("k%d-%d", g, i)
+				assert.NoError(t, c.Set("b1", key, []byte(key), Options{}))
+				v, err := c.Get("b1", key, Options{})
+				assert.NoError(t, err)
+				assert.Equal(t, []byte(key), v)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
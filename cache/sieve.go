@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"container/list"
+)
+
+var _ sharedAccessEvictor = &sieveEvictor{}
+
+// sieveEvictor implements [EvictionPolicySIEVE] from https://sievecache.com
+// New entries are added to the head of a FIFO queue. A hit only sets the visited bit,
+// so [LRUCache.Get] can hold the read lock. On eviction the hand moves from tail
+// to head, clearing the visited bit until it finds an entry that is not visited.
+// Unlike CLOCK, surviving entries stay in place instead of being moved to the head.
+type sieveEvictor struct {
+	// queue front is the head i.e. the newest entry.
+	queue *list.List
+	// hand points to the next entry to check, nil means the tail.
+	hand *list.Element
+}
+
+func newSIEVEEvictor() *sieveEvictor {
+	return &sieveEvictor{
+		queue: list.New(),
+	}
+}
+
+func (s *sieveEvictor) add(e *cacheEntry) {
+	e.visited.Store(false)
+	e.elem = s.queue.PushFront(e)
+}
+
+func (s *sieveEvictor) access(e *cacheEntry) {
+	e.visited.Store(true)
+}
+
+func (s *sieveEvictor) sharedAccess() {}
+
+func (s *sieveEvictor) remove(e *cacheEntry) {
+	if s.hand == e.elem {
+		s.hand = e.elem.Prev()
+	}
+	s.queue.Remove(e.elem)
+	e.elem = nil
+}
+
+func (s *sieveEvictor) victim() *cacheEntry {
+	if s.queue.Len() == 0 {
+		return nil
+	}
+	elem := s.hand
+	if elem == nil {
+		elem = s.queue.Back()
+	}
+	for {
+		e := elem.Value.(*cacheEntry)
+		if !e.visited.Load() {
+			s.hand = elem.Prev()
+			return e
+		}
+		e.visited.Store(false)
+		elem = elem.Prev()
+		if elem == nil {
+			elem = s.queue.Back()
+		}
+	}
+}
+
+// victimIn prefers the oldest entry that is not visited without moving the hand.
+func (s *sieveEvictor) victimIn(bucket string) *cacheEntry {
+	for elem := s.queue.Back(); elem != nil; elem = elem.Prev() {
+		if e := elem.Value.(*cacheEntry); e.bucket == bucket && !e.visited.Load() {
+			return e
+		}
+	}
+	return lastIn(s.queue, bucket)
+}
+
+func (s *sieveEvictor) len() int {
+	return s.queue.Len()
+}
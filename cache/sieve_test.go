@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSIEVEEvictor(t *testing.T) {
+	s := newSIEVEEvictor()
+	entries := make([]*cacheEntry, 4)
+	for i := range entries {
+		entries[i] = &cacheEntry{key: fmt.Sprintf("k%d", i)}
+		s.add(entries[i])
+	}
+	s.access(entries[0])
+	s.access(entries[2])
+
+	// k0 is visited, hand moves to k1
+	v := s.victim()
+	assert.Equal(t, entries[1], v)
+	assert.False(t, entries[0].visited.Load())
+	s.remove(v)
+
+	// k2 is visited, hand moves to k3
+	v = s.victim()
+	assert.Equal(t, entries[3], v)
+	s.remove(v)
+
+	// Wraps around to tail, k0 visited bit was cleared
+	v = s.victim()
+	assert.Equal(t, entries[0], v)
+	s.remove(v)
+	assert.Equal(t, 1, s.len())
+}
+
+func TestSIEVECache(t *testing.T) {
+	c := newTestCache(t, 3, 0, WithEvictionPolicy(EvictionPolicySIEVE))
+	c.Set("b1", "k1", []byte("v1"), Options{})
+	c.Set("b1", "k2", []byte("v2"), Options{})
+	v, err := c.Get("b1", "k1", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), v)
+	assert.True(t, c.buckets["b1"]["k1"].visited.Load())
+
+	c.Set("b1", "k3", []byte("v3"), Options{})
+	c.Set("b1", "k4", []byte("v4"), Options{})
+	_, ok := c.buckets["b1"]["k2"]
+	assert.False(t, ok)
+	_, ok = c.buckets["b1"]["k1"]
+	assert.True(t, ok)
+}
+
+func TestSharedGetFullCache(t *testing.T) {
+	for _, policy := range []EvictionPolicy{EvictionPolicySIEVE, EvictionPolicyCLOCK} {
+		t.Run(policy.String(), func(t *testing.T) {
+			c := newTestCache(t, 3, 0, WithEvictionPolicy(policy))
+			for _, k := range []string{"k1", "k2", "k3"} {
+				c.Set("b1", k, []byte("v"), Options{})
+			}
+			// Full cache is the steady state, hit is still served under the read lock
+			_, _, ok := c.getShared("b1", "k1")
+			assert.True(t, ok)
+			_, err := c.Get("b1", "k2", Options{})
+			assert.NoError(t, err)
+			assert.Equal(t, 3, c.evictor.len())
+		})
+	}
+}
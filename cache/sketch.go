@@ -0,0 +1,76 @@
+package cache
+
+// cmSketch is a count-min sketch that estimates the access frequency of keys
+// for [tinyLFUEvictor]. Counters are saturated at 15 like 4 bit counters in Caffeine
+// and halved periodically so old popularity fades away.
+type cmSketch struct {
+	rows [cmDepth][]uint8
+	mask uint64
+	// additions is number of increments since last reset.
+	additions int
+	// resetAt is the sample size, all counters are halved when additions reaches it.
+	resetAt int
+}
+
+const (
+	cmDepth      = 4
+	cmMaxCounter = 15
+)
+
+// newCMSketch creates a sketch for a cache holding capacity keys.
+// Like Ristretto, it has 10x counters of capacity to track keys not in the cache.
+func newCMSketch(capacity int) *cmSketch {
+	width := 16
+	for width < 10*capacity {
+		width *= 2
+	}
+	s := &cmSketch{
+		mask:    uint64(width - 1),
+		resetAt: 10 * max(capacity, 1),
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// Seeds to derive a different index for each row, same as Caffeine's FrequencySketch.
+var cmSeeds = [cmDepth]uint64{0xc3a5c85c97cb3127, 0xb492b66fbe98f273, 0x9ae16a3b2f90404f, 0xcbf29ce484222325}
+
+// index derives the index for each row from a single hash.
+func (s *cmSketch) index(h uint64, row int) uint64 {
+	h = (h + cmSeeds[row]) * cmSeeds[row]
+	h += h >> 32
+	return h & s.mask
+}
+
+func (s *cmSketch) increment(h uint64) {
+	for i := range s.rows {
+		idx := s.index(h, i)
+		if s.rows[i][idx] < cmMaxCounter {
+			s.rows[i][idx]++
+		}
+	}
+	s.additions++
+	if s.additions >= s.resetAt {
+		s.reset()
+	}
+}
+
+func (s *cmSketch) estimate(h uint64) uint8 {
+	est := uint8(cmMaxCounter)
+	for i := range s.rows {
+		est = min(est, s.rows[i][s.index(h, i)])
+	}
+	return est
+}
+
+// reset halves all the counters for aging.
+func (s *cmSketch) reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] /= 2
+		}
+	}
+	s.additions /= 2
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"slices"
+)
+
+// InvalidateTag deletes all the entries with the tag in all the buckets
+// and returns the number of deleted entries.
+func (c *LRUCache) InvalidateTag(tag string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for e := range c.tags[tag] {
+		c.del(e)
+		c.metrics.AddDelete()
+		n++
+	}
+	if n > 0 {
+		c.reportSize()
+	}
+	return n
+}
+
+// tag adds the entry to the reverse index of its [Options.Tags].
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) tag(e *cacheEntry) {
+	for _, tag := range e.opts.Tags {
+		entries, ok := c.tags[tag]
+		if !ok {
+			entries = make(map[*cacheEntry]struct{})
+			c.tags[tag] = entries
+		}
+		entries[e] = struct{}{}
+	}
+}
+
+// untag removes the entry from the reverse index, tag without entries is removed.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) untag(e *cacheEntry) {
+	for _, tag := range e.opts.Tags {
+		delete(c.tags[tag], e)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
+
+// normalizeTags removes empty and duplicated tags, it returns a copy
+// because caller may reuse the slice in [Options].
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	tags = slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == "" })
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidateTag(t *testing.T) {
+	metrics := &deleteMetrics{}
+	c, err := NewLRUCache(100, 0, metrics)
+	require.NoError(t, err)
+
+	tags := []string{"product:42", "tenant:acme", "product:42", ""}
+	require.NoError(t, c.Set("pages", "p1", []byte("v"), Options{Tags: tags}))
+	tags[0] = "changed"
+	require.NoError(t, c.Set("prices", "p42", []byte("v"), Options{Tags: []string{"product:42"}}))
+	require.NoError(t, c.Set("pages", "p2", []byte("v"), Options{Tags: []string{"tenant:acme"}}))
+	require.NoError(t, c.Set("pages", "p3", []byte("v"), Options{}))
+	assert.Equal(t, []string{"product:42", "tenant:acme"}, c.buckets["pages"]["p1"].opts.Tags)
+
+	assert.Equal(t, 2, c.InvalidateTag("product:42"))
+	assert.Equal(t, 2, metrics.deletes)
+	_, err = c.Get("prices", "p42", Options{})
+	assert.True(t, IsMiss(err))
+	assert.Equal(t, 0, c.InvalidateTag("product:42"))
+
+	// Set replaces tags
+	require.NoError(t, c.Set("pages", "p2", []byte("v"), Options{Tags: []string{"tenant:other"}}))
+	assert.Equal(t, 0, c.InvalidateTag("tenant:acme"))
+	assert.NotContains(t, c.tags, "tenant:acme")
+
+	// Index is cleaned up on delete and evict
+	require.NoError(t, c.Delete("pages", "p2"))
+	assert.Empty(t, c.tags)
+	c2 := newTestCache(t, 1, 0)
+	require.NoError(t, c2.Set("b1", "k1", []byte("v"), Options{Tags: []string{"t1"}}))
+	require.NoError(t, c2.Set("b1", "k2", []byte("v"), Options{}))
+	assert.Empty(t, c2.tags)
+}
+
+func TestParseTags(t *testing.T) {
+	r := httptest.NewRequest("PUT", "/cache/b1/k1?tags=a,b&tags=c", nil)
+	r.Header.Add("X-Cache-Tags", "d, e")
+	opts, err := ParseFromRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, opts.Tags)
+}
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"container/list"
+	"hash/maphash"
+)
+
+var _ evictor = &tinyLFUEvictor{}
+
+// Segments of [tinyLFUEvictor], stored in [cacheEntry] segment.
+const (
+	segmentWindow uint8 = iota
+	segmentProbation
+	segmentProtected
+)
+
+// tinyLFUEvictor implements [EvictionPolicyTinyLFU] using Window-TinyLFU from
+// https://arxiv.org/abs/1512.00727 which is also used by Caffeine and Ristretto.
+//
+// New entries enter a small window LRU. When the cache is full, the oldest entry
+// in the window competes with the victim of the main segmented LRU (SLRU), the one
+// with lower estimated frequency in the [cmSketch] is evicted. This keeps one off
+// scans from flushing out frequently used entries.
+type tinyLFUEvictor struct {
+	metrics MetricsHandler
+	seed    maphash.Seed
+	sketch  *cmSketch
+
+	windowCap    int
+	protectedCap int
+
+	window *list.List
+	// probation and protected make up the main SLRU.
+	// Entries hit in probation are promoted to protected.
+	probation *list.List
+	protected *list.List
+}
+
+func newTinyLFUEvictor(capacity int, metrics MetricsHandler) *tinyLFUEvictor {
+	// 1% window and 80% of main for protected, same as Caffeine's default.
+	windowCap := max(1, capacity/100)
+	mainCap := max(0, capacity-windowCap)
+	return &tinyLFUEvictor{
+		metrics:      metrics,
+		seed:         maphash.MakeSeed(),
+		sketch:       newCMSketch(capacity),
+		windowCap:    windowCap,
+		protectedCap: mainCap * 80 / 100,
+		window:       list.New(),
+		probation:    list.New(),
+		protected:    list.New(),
+	}
+}
+
+func (t *tinyLFUEvictor) hash(e *cacheEntry) uint64 {
+	var h maphash.Hash
+	h.SetSeed(t.seed)
+	h.WriteString(e.bucket)
+	h.WriteByte(0)
+	h.WriteString(e.key)
+	return h.Sum64()
+}
+
+// add puts new entry in the window and moves the overflow to probation.
+// The cache is not full otherwise victim is called before add.
+func (t *tinyLFUEvictor) add(e *cacheEntry) {
+	t.sketch.increment(t.hash(e))
+	e.segment = segmentWindow
+	e.elem = t.window.PushFront(e)
+	if t.window.Len() > t.windowCap {
+		t.moveTo(t.window.Back().Value.(*cacheEntry), segmentProbation)
+	}
+}
+
+func (t *tinyLFUEvictor) access(e *cacheEntry) {
+	t.sketch.increment(t.hash(e))
+	switch e.segment {
+	case segmentWindow:
+		t.window.MoveToFront(e.elem)
+	case segmentProbation:
+		t.moveTo(e, segmentProtected)
+		if t.protected.Len() > t.protectedCap {
+			t.moveTo(t.protected.Back().Value.(*cacheEntry), segmentProbation)
+		}
+	case segmentProtected:
+		t.protected.MoveToFront(e.elem)
+	}
+}
+
+func (t *tinyLFUEvictor) remove(e *cacheEntry) {
+	t.list(e.segment).Remove(e.elem)
+	e.elem = nil
+}
+
+// victim is called when the cache is full. The oldest entry in window is the candidate
+// to enter main, it is admitted only if it is used more frequently than main's victim.
+func (t *tinyLFUEvictor) victim() *cacheEntry {
+	candidate := t.window.Back()
+	mainVictim := t.probation.Back()
+	if mainVictim == nil {
+		mainVictim = t.protected.Back()
+	}
+	if candidate == nil || mainVictim == nil {
+		if candidate != nil {
+			return candidate.Value.(*cacheEntry)
+		}
+		if mainVictim != nil {
+			return mainVictim.Value.(*cacheEntry)
+		}
+		return nil
+	}
+
+	c := candidate.Value.(*cacheEntry)
+	v := mainVictim.Value.(*cacheEntry)
+	if t.sketch.estimate(t.hash(c)) > t.sketch.estimate(t.hash(v)) {
+		// Admit the candidate, it leaves the window to make room for the new entry.
+		t.moveTo(c, segmentProbation)
+		return v
+	}
+	t.metrics.AddReject()
+	return c
+}
+
+// victimIn skips the admission, it prefers probation, then window and protected.
+func (t *tinyLFUEvictor) victimIn(bucket string) *cacheEntry {
+	for _, l := range []*list.List{t.probation, t.window, t.protected} {
+		if e := lastIn(l, bucket); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (t *tinyLFUEvictor) len() int {
+	return t.window.Len() + t.probation.Len() + t.protected.Len()
+}
+
+func (t *tinyLFUEvictor) list(segment uint8) *list.List {
+	switch segment {
+	case segmentWindow:
+		return t.window
+	case segmentProbation:
+		return t.probation
+	default:
+		return t.protected
+	}
+}
+
+// moveTo removes entry from its current segment and puts it at the front of the new segment.
+func (t *tinyLFUEvictor) moveTo(e *cacheEntry, segment uint8) {
+	t.list(e.segment).Remove(e.elem)
+	e.segment = segment
+	e.elem = t.list(segment).PushFront(e)
+}
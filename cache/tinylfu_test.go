@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rejectMetrics struct {
+	noopMetrics
+	rejected int
+}
+
+func (m *rejectMetrics) AddReject() {
+	m.rejected++
+}
+
+func TestCMSketch(t *testing.T) {
+	s := newCMSketch(10)
+	for i := 0; i < 5; i++ {
+		s.increment(42)
+	}
+	assert.Equal(t, uint8(5), s.estimate(42))
+
+	// Saturated
+	for i := 0; i < 20; i++ {
+		s.increment(42)
+	}
+	assert.Equal(t, uint8(cmMaxCounter), s.estimate(42))
+
+	// Aging halves the counters
+	s.reset()
+	assert.Equal(t, uint8(cmMaxCounter/2), s.estimate(42))
+}
+
+func TestTinyLFUScanResistance(t *testing.T) {
+	metrics := &rejectMetrics{}
+	c, err := NewLRUCache(100, 0, metrics, WithEvictionPolicy(EvictionPolicyTinyLFU))
+	require.NoError(t, err)
+
+	hot := 10
+	for i := 0; i < hot; i++ {
+		c.Set("b1", fmt.Sprintf("hot%d", i), []byte("v"), Options{})
+	}
+	for round := 0; round < 5; round++ {
+		for i := 0; i < hot; i++ {
+			_, err := c.Get("b1", fmt.Sprintf("hot%d", i), Options{})
+			require.NoError(t, err)
+		}
+	}
+
+	// Scan keys are only used once, they are rejected instead of evicting hot keys.
+	for i := 0; i < 1000; i++ {
+		c.Set("b1", fmt.Sprintf("scan%d", i), []byte("v"), Options{})
+	}
+	for i := 0; i < hot; i++ {
+		_, ok := c.buckets["b1"][fmt.Sprintf("hot%d", i)]
+		assert.True(t, ok, "hot%d", i)
+	}
+	assert.Positive(t, metrics.rejected)
+	assert.LessOrEqual(t, c.evictor.len(), 100)
+}
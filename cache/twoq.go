@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"container/list"
+)
+
+var _ evictor = &twoQEvictor{}
+
+// Segments of [twoQEvictor], stored in [cacheEntry] segment.
+const (
+	segment2QRecent uint8 = iota
+	segment2QFrequent
+)
+
+// Same default ratio as TwoQueueCache in https://github.com/hashicorp/golang-lru
+const (
+	twoQRecentRatio = 0.25
+	twoQGhostRatio  = 0.5
+)
+
+// twoQEvictor implements [EvictionPolicy2Q] from https://www.vldb.org/conf/1994/P439.PDF
+// New entries are in recent, entries used again are promoted to frequent.
+// Keys evicted from recent are remembered in a ghost list, they go to frequent
+// directly if they are added again.
+type twoQEvictor struct {
+	recentCap int
+	ghostCap  int
+
+	recent      *list.List
+	frequent    *list.List
+	recentEvict *ghostList
+}
+
+func newTwoQEvictor(capacity int) *twoQEvictor {
+	return &twoQEvictor{
+		recentCap:   int(float64(capacity) * twoQRecentRatio),
+		ghostCap:    int(float64(capacity) * twoQGhostRatio),
+		recent:      list.New(),
+		frequent:    list.New(),
+		recentEvict: newGhostList(),
+	}
+}
+
+func (q *twoQEvictor) add(e *cacheEntry) {
+	if q.recentEvict.remove(ghostKey(e)) {
+		q.push(e, segment2QFrequent)
+		return
+	}
+	q.push(e, segment2QRecent)
+}
+
+func (q *twoQEvictor) access(e *cacheEntry) {
+	if e.segment == segment2QRecent {
+		q.recent.Remove(e.elem)
+		q.push(e, segment2QFrequent)
+		return
+	}
+	q.frequent.MoveToFront(e.elem)
+}
+
+func (q *twoQEvictor) remove(e *cacheEntry) {
+	q.list(e.segment).Remove(e.elem)
+	e.elem = nil
+}
+
+// victim evicts from recent if it is over its share, the key is remembered in the ghost list.
+func (q *twoQEvictor) victim() *cacheEntry {
+	if q.recent.Len() > 0 && (q.recent.Len() > q.recentCap || q.frequent.Len() == 0) {
+		e := q.recent.Back().Value.(*cacheEntry)
+		q.recentEvict.push(ghostKey(e))
+		for q.recentEvict.len() > q.ghostCap {
+			q.recentEvict.removeOldest()
+		}
+		return e
+	}
+	if back := q.frequent.Back(); back != nil {
+		return back.Value.(*cacheEntry)
+	}
+	return nil
+}
+
+func (q *twoQEvictor) victimIn(bucket string) *cacheEntry {
+	if e := lastIn(q.recent, bucket); e != nil {
+		return e
+	}
+	return lastIn(q.frequent, bucket)
+}
+
+func (q *twoQEvictor) len() int {
+	return q.recent.Len() + q.frequent.Len()
+}
+
+func (q *twoQEvictor) list(segment uint8) *list.List {
+	if segment == segment2QRecent {
+		return q.recent
+	}
+	return q.frequent
+}
+
+func (q *twoQEvictor) push(e *cacheEntry, segment uint8) {
+	e.segment = segment
+	e.elem = q.list(segment).PushFront(e)
+}
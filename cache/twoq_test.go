@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoQEvictor(t *testing.T) {
+	q := newTwoQEvictor(4)
+	entries := make([]*cacheEntry, 4)
+	for i := range entries {
+		entries[i] = &cacheEntry{bucket: "b1", key: fmt.Sprintf("k%d", i)}
+		q.add(entries[i])
+	}
+	q.access(entries[0])
+	assert.Equal(t, segment2QFrequent, entries[0].segment)
+
+	// Recent is over its share, evict oldest in recent and remember it.
+	v := q.victim()
+	assert.Equal(t, entries[1], v)
+	q.remove(v)
+	assert.Equal(t, 1, q.recentEvict.len())
+
+	// Added again after eviction, goes to frequent.
+	e := &cacheEntry{bucket: "b1", key: "k1"}
+	q.add(e)
+	assert.Equal(t, segment2QFrequent, e.segment)
+	assert.Equal(t, 0, q.recentEvict.len())
+}
@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TxOpType is the type of [TxOp].
+type TxOpType int
+
+const (
+	// TxSet sets Value with Opts.
+	TxSet TxOpType = iota + 1
+	// TxDelete deletes the key, missing key is not an error.
+	TxDelete
+	// TxIncrement adds Delta like [Cache.Increment], missing key starts from Initial.
+	TxIncrement
+	// TxCheck only checks Version without changing the key, like WATCH in redis.
+	TxCheck
+)
+
+// TxOp is an operation in [Cache.Transaction].
+type TxOp struct {
+	Type   TxOpType
+	Bucket string
+	Key    string
+	// Value of TxSet.
+	Value []byte
+	// Delta and Initial of TxIncrement.
+	Delta   int64
+	Initial int64
+	// Opts of TxSet, TxIncrement only uses it for new key.
+	Opts Options
+	// CheckVersion guards the op with Version like [Cache.CompareAndSet],
+	// 0 means the key must not exist. TxCheck always checks the version.
+	CheckVersion bool
+	Version      uint64
+}
+
+// TxResult is the result of a [TxOp] in the same order as ops.
+type TxResult struct {
+	// Version of the key after the op, 0 if the key is deleted.
+	Version uint64
+	// Value is the result of TxIncrement.
+	Value int64
+}
+
+// Transaction applies all the ops or none of them under the lock.
+// Versions are checked before any op is applied, so they should come from Get
+// before the transaction. The error is wrapped with the index of the failed op.
+// NOTE: Like Set, a new key may evict other keys including keys set earlier in the transaction.
+func (c *LRUCache) Transaction(ops []TxOp) ([]TxResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i, err := c.validateTx(ops); err != nil {
+		return nil, txError(i, err)
+	}
+	results := make([]TxResult, len(ops))
+	c.applyTx(ops, results)
+	c.reportSize()
+	return results, nil
+}
+
+// Transaction locks the shards of all the keys in index order, so concurrent
+// transactions don't deadlock, then works like [LRUCache.Transaction].
+// All the shards are validated before any shard is changed.
+func (c *ShardedCache) Transaction(ops []TxOp) ([]TxResult, error) {
+	groups := c.groupKeys(len(ops), func(i int) (string, string) {
+		return ops[i].Bucket, ops[i].Key
+	})
+	var shards []*LRUCache
+	for _, shard := range c.shards {
+		if _, ok := groups[shard]; ok {
+			shard.mu.Lock()
+			defer shard.mu.Unlock()
+			shards = append(shards, shard)
+		}
+	}
+
+	shardOps := make(map[*LRUCache][]TxOp, len(shards))
+	for _, shard := range shards {
+		indexes := groups[shard]
+		for _, i := range indexes {
+			shardOps[shard] = append(shardOps[shard], ops[i])
+		}
+		if i, err := shard.validateTx(shardOps[shard]); err != nil {
+			return nil, txError(indexes[i], err)
+		}
+	}
+	results := make([]TxResult, len(ops))
+	for _, shard := range shards {
+		indexes := groups[shard]
+		shardResults := make([]TxResult, len(indexes))
+		shard.applyTx(shardOps[shard], shardResults)
+		for j, r := range shardResults {
+			results[indexes[j]] = r
+		}
+		shard.reportSize()
+	}
+	return results, nil
+}
+
+type txKey struct {
+	bucket string
+	key    string
+}
+
+// txValue is the value of a key after earlier ops in the transaction.
+type txValue struct {
+	value  []byte
+	exists bool
+}
+
+// validateTx checks all the ops without changing the cache, so applyTx does not fail halfway.
+// It returns the index of the failed op.
+// NOTE: caller must hold the write lock.
+func (c *LRUCache) validateTx(ops []TxOp) (int, error) {
+	for i, op := range ops {
+		if op.Type < TxSet || op.Type > TxCheck {
+			return i, keyError(op.Bucket, op.Key, fmt.Errorf("type %d: %w", op.Type, ErrInvalidOp))
+		}
+		if op.Type == TxCheck || op.CheckVersion {
+			if c.currentVersion(op.Bucket, op.Key) != op.Version {
+				return i, keyError(op.Bucket, op.Key, ErrVersionMismatch)
+			}
+		}
+	}
+
+	pending := make(map[txKey]txValue)
+	for i, op := range ops {
+		k := txKey{bucket: op.Bucket, key: op.Key}
+		v, ok := pending[k]
+		if !ok {
+			if entry := c.existing(op.Bucket, op.Key); entry != nil {
+				v = txValue{value: entry.value, exists: true}
+			}
+		}
+		switch op.Type {
+		case TxSet:
+			v = txValue{value: op.Value, exists: true}
+		case TxDelete:
+			v = txValue{}
+		case TxIncrement:
+			n, err := incrementValue(v.value, v.exists, op.Delta, op.Initial)
+			if err != nil {
+				return i, keyError(op.Bucket, op.Key, err)
+			}
+			v = txValue{value: strconv.AppendInt(nil, n, 10), exists: true}
+		}
+		if v.exists {
+			if err := c.checkSize(op.Bucket, op.Key, entrySize(op.Bucket, op.Key, v.value)); err != nil {
+				return i, err
+			}
+		}
+		pending[k] = v
+	}
+	return 0, nil
+}
+
+// applyTx applies validated ops in order and fills results.
+// It must not fail once validateTx passes, otherwise the transaction is partially
+// applied (across shards for [ShardedCache]), so it panics instead of returning the error.
+// Any new error path in set or increment must be checked in validateTx.
+// NOTE: caller must hold the write lock and call validateTx.
+func (c *LRUCache) applyTx(ops []TxOp, results []TxResult) {
+	for i, op := range ops {
+		switch op.Type {
+		case TxSet:
+			c.metrics.AddSet()
+			entry, err := c.set(op.Bucket, op.Key, op.Value, op.Opts)
+			if err != nil {
+				panic(fmt.Sprintf("%v after validation", txError(i, err)))
+			}
+			results[i].Version = entry.version
+		case TxDelete:
+			c.metrics.AddDelete()
+			if entry, err := c.lookup(op.Bucket, op.Key); err == nil {
+				c.del(entry)
+			}
+		case TxIncrement:
+			c.metrics.AddSet()
+			n, err := c.increment(op.Bucket, op.Key, op.Delta, op.Initial, op.Opts)
+			if err != nil {
+				panic(fmt.Sprintf("%v after validation", txError(i, err)))
+			}
+			results[i].Value = n
+			results[i].Version = c.currentVersion(op.Bucket, op.Key)
+		case TxCheck:
+			results[i].Version = c.currentVersion(op.Bucket, op.Key)
+		}
+	}
+}
+
+func txError(i int, err error) error {
+	return fmt.Errorf("transaction op %d: %w", i, err)
+}
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction(t *testing.T) {
+	lru := newTestCache(t, 100, 0, WithMaxBytes(100))
+	sharded, err := NewShardedCache(4, 100, 0, &noopMetrics{}, WithMaxBytes(400))
+	require.NoError(t, err)
+
+	for _, c := range []Cache{lru, sharded} {
+		t.Run(fmt.Sprintf("%T", c), func(t *testing.T) {
+			require.NoError(t, c.Set("users", "u1", []byte("alice"), Options{}))
+			require.NoError(t, c.Set("stats", "count", []byte("1"), Options{}))
+			require.NoError(t, c.Set("stats", "name", []byte("n"), Options{}))
+			_, version, err := c.GetWithVersion("users", "u1", Options{})
+			require.NoError(t, err)
+
+			results, err := c.Transaction([]TxOp{
+				{Type: TxSet, Bucket: "users", Key: "u1", Value: []byte("bob"), CheckVersion: true, Version: version},
+				{Type: TxIncrement, Bucket: "stats", Key: "count", Delta: 2},
+				{Type: TxSet, Bucket: "users", Key: "u2", Value: []byte("10"), CheckVersion: true},
+				{Type: TxIncrement, Bucket: "users", Key: "u2", Delta: 1},
+				{Type: TxDelete, Bucket: "stats", Key: "name"},
+				{Type: TxDelete, Bucket: "stats", Key: "missing"},
+			})
+			require.NoError(t, err)
+			require.Len(t, results, 6)
+			assert.Greater(t, results[0].Version, version)
+			assert.Equal(t, int64(3), results[1].Value)
+			assert.Equal(t, int64(11), results[3].Value)
+			assert.Greater(t, results[3].Version, results[2].Version)
+			assertValue(t, c, "users", "u1", "bob")
+			assertValue(t, c, "users", "u2", "11")
+			_, err = c.Get("stats", "name", Options{})
+			assert.True(t, IsMiss(err))
+
+			// Nothing is applied if any op fails
+			failed := []struct {
+				op  TxOp
+				err error
+			}{
+				{TxOp{Type: TxCheck, Bucket: "users", Key: "u1", Version: version}, ErrVersionMismatch},
+				{TxOp{Type: TxIncrement, Bucket: "users", Key: "u1", Delta: 1}, ErrNotInteger},
+				{TxOp{Type: TxSet, Bucket: "users", Key: "u3", Value: make([]byte, 200)}, ErrTooLarge},
+				{TxOp{Type: 0, Bucket: "users", Key: "u3"}, ErrInvalidOp},
+			}
+			for _, f := range failed {
+				_, err = c.Transaction([]TxOp{
+					{Type: TxSet, Bucket: "stats", Key: "count", Value: []byte("100")},
+					{Type: TxDelete, Bucket: "users", Key: "u2"},
+					f.op,
+				})
+				assert.ErrorIs(t, err, f.err)
+				assert.ErrorContains(t, err, "transaction op 2")
+				assertValue(t, c, "stats", "count", "3")
+				assertValue(t, c, "users", "u2", "11")
+			}
+
+			results, err = c.Transaction(nil)
+			require.NoError(t, err)
+			assert.Empty(t, results)
+		})
+	}
+}
+
+func assertValue(t *testing.T, c Cache, bucket string, key string, expected string) {
+	t.Helper()
+	value, err := c.Get(bucket, key, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(value))
+}
+
+func TestShardedTransactionConcurrent(t *testing.T) {
+	c, err := NewShardedCache(8, 100, 0, &noopMetrics{})
+	require.NoError(t, err)
+
+	// Keys in opposite order would deadlock if shards are not locked in index order
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				ops := make([]TxOp, len(keys))
+				for i, key := range keys {
+					if g%2 == 1 {
+						key = keys[len(keys)-1-i]
+					}
+					ops[i] = TxOp{Type: TxIncrement, Bucket: "b1", Key: key, Delta: 1}
+				}
+				_, err := c.Transaction(ops)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, key := range keys {
+		assertValue(t, c, "b1", key, "400")
+	}
+}
+
+func TestApplyTxPanicsWithoutValidation(t *testing.T) {
+	c := newTestCache(t, 100, 0, WithMaxBytes(100))
+	ops := []TxOp{{Type: TxSet, Bucket: "b1", Key: "k1", Value: make([]byte, 200)}}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.PanicsWithValue(t, "transaction op 0: bucket b1 key k1: value too large after validation", func() {
+		c.applyTx(ops, make([]TxResult, len(ops)))
+	})
+}
+
+func TestShardedTransaction(t *testing.T) {
+	c, err := NewShardedCache(4, 100, 0, &noopMetrics{})
+	require.NoError(t, err)
+	keys := keysInShards(t, c, "b1", 4)
+	versions := make([]uint64, len(keys))
+	for i, key := range keys {
+		require.NoError(t, c.Set("b1", key, []byte("v"), Options{}))
+		_, versions[i], err = c.GetWithVersion("b1", key, Options{})
+		require.NoError(t, err)
+	}
+
+	// Version mismatch on the last shard, earlier shards are validated first but not changed
+	ops := []TxOp{
+		{Type: TxSet, Bucket: "b1", Key: keys[0], Value: []byte("new")},
+		{Type: TxDelete, Bucket: "b1", Key: keys[1]},
+		{Type: TxIncrement, Bucket: "b1", Key: "n", Delta: 1},
+		{Type: TxSet, Bucket: "b1", Key: keys[2], Value: []byte("new"), CheckVersion: true, Version: versions[2]},
+		{Type: TxSet, Bucket: "b1", Key: keys[3], Value: []byte("new"), CheckVersion: true, Version: versions[3] + 1},
+	}
+	_, err = c.Transaction(ops)
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+	assert.ErrorContains(t, err, "transaction op 4")
+	for i, key := range keys {
+		value, version, err := c.GetWithVersion("b1", key, Options{})
+		require.NoError(t, err)
+		assert.Equal(t, "v", string(value))
+		assert.Equal(t, versions[i], version)
+	}
+	_, err = c.Get("b1", "n", Options{})
+	assert.True(t, IsMiss(err))
+
+	ops[4].Version = versions[3]
+	results, err := c.Transaction(ops)
+	require.NoError(t, err)
+	assert.Greater(t, results[0].Version, versions[0])
+	assert.Zero(t, results[1].Version)
+	assert.Equal(t, int64(1), results[2].Value)
+	assertValue(t, c, "b1", keys[0], "new")
+	assertValue(t, c, "b1", keys[3], "new")
+	_, err = c.Get("b1", keys[1], Options{})
+	assert.True(t, IsMiss(err))
+}
+
+func TestTransactionDuplicateKeys(t *testing.T) {
+	lru := newTestCache(t, 100, 0)
+	sharded, err := NewShardedCache(4, 100, 0, &noopMetrics{})
+	require.NoError(t, err)
+
+	for _, c := range []Cache{lru, sharded} {
+		t.Run(fmt.Sprintf("%T", c), func(t *testing.T) {
+			require.NoError(t, c.Set("b1", "k1", []byte("1"), Options{}))
+			_, version, err := c.GetWithVersion("b1", "k1", Options{})
+			require.NoError(t, err)
+
+			// Later ops see the value of earlier ops, version guards see the value before the transaction
+			results, err := c.Transaction([]TxOp{
+				{Type: TxIncrement, Bucket: "b1", Key: "k1", Delta: 1},
+				{Type: TxCheck, Bucket: "b1", Key: "k1", Version: version},
+				{Type: TxIncrement, Bucket: "b1", Key: "k1", Delta: 2},
+				{Type: TxDelete, Bucket: "b1", Key: "k1"},
+				{Type: TxIncrement, Bucket: "b1", Key: "k1", Delta: 1, Initial: 10},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), results[0].Value)
+			assert.Equal(t, results[0].Version, results[1].Version)
+			assert.Equal(t, int64(4), results[2].Value)
+			assert.Zero(t, results[3].Version)
+			assert.Equal(t, int64(11), results[4].Value)
+			assertValue(t, c, "b1", "k1", "11")
+
+			// Increment fails on the value set earlier in the transaction, nothing is applied
+			_, err = c.Transaction([]TxOp{
+				{Type: TxSet, Bucket: "b1", Key: "k1", Value: []byte("a")},
+				{Type: TxIncrement, Bucket: "b1", Key: "k1", Delta: 1},
+			})
+			assert.ErrorIs(t, err, ErrNotInteger)
+			assert.ErrorContains(t, err, "transaction op 1")
+			assertValue(t, c, "b1", "k1", "11")
+		})
+	}
+}
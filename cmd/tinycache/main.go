@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
@@ -22,9 +24,18 @@ import (
 
 var (
 	// server flags
-	useGRPC bool
-	port    int
-	host    string
+	useGRPC  bool
+	port     int
+	host     string
+	policy   string
+	shards   int
+	capacity int
+	maxBytes int64
+	// default limits of each bucket
+	bucketMaxEntries int
+	bucketMaxBytes   int64
+	// origin to refresh entries with soft ttl
+	loaderURL string
 
 	// client flags
 	clientHost string
@@ -55,6 +66,13 @@ func main() {
 	serverCmd.Flags().BoolVar(&useGRPC, "grpc", false, "Use gRPC server instead of HTTP")
 	serverCmd.Flags().IntVar(&port, "port", 8080, "Port to listen on")
 	serverCmd.Flags().StringVar(&host, "host", "0.0.0.0", "Host address to bind to")
+	serverCmd.Flags().IntVar(&capacity, "capacity", 10, "Max number of keys in the cache across all buckets and shards")
+	serverCmd.Flags().Int64Var(&maxBytes, "max-bytes", 0, "Max size of buckets, keys and values in bytes, 0 means no limit")
+	serverCmd.Flags().IntVar(&bucketMaxEntries, "bucket-max-entries", 0, "Default max number of keys in each bucket, 0 means no limit")
+	serverCmd.Flags().Int64Var(&bucketMaxBytes, "bucket-max-bytes", 0, "Default max size of each bucket in bytes, 0 means no limit")
+	serverCmd.Flags().IntVar(&shards, "shards", 1, "Number of cache shards, each shard has its own lock")
+	serverCmd.Flags().StringVar(&loaderURL, "loader-url", "", "Origin to refresh entries with soft_ttl from using GET <url>/<bucket>/<key>, empty disables soft_ttl")
+	serverCmd.Flags().StringVar(&policy, "policy", "lru", "Eviction policy for the entire cache: lru, mru, oldest, newest, lfu, tinylfu, arc, 2q, sieve, clock")
 
 	// Client flags
 	clientCmd.Flags().StringVar(&clientHost, "host", "localhost", "Server host to connect to")
@@ -69,16 +87,36 @@ func main() {
 }
 
 func runServer(cmd *cobra.Command, args []string) {
+	evictionPolicy, err := cache.ParseEvictionPolicy(policy)
+	if err != nil {
+		log.Fatalf("Invalid policy: %v", err)
+	}
 	metrics := cache.NewPrometheusMetrics()
-	cache := cache.NewLRUCache(10, 500*time.Millisecond, metrics)
+	opts := []cache.Option{
+		cache.WithEvictionPolicy(evictionPolicy),
+		cache.WithMaxBytes(maxBytes),
+		cache.WithDefaultBucketConfig(cache.BucketConfig{MaxEntries: bucketMaxEntries, MaxBytes: bucketMaxBytes}),
+	}
+	if loaderURL != "" {
+		opts = append(opts, cache.WithLoader(cache.NewHTTPLoader(loaderURL, &http.Client{Timeout: 10 * time.Second})))
+	}
+	var c cache.Cache
+	if shards > 1 {
+		c, err = cache.NewShardedCache(shards, capacity, 500*time.Millisecond, metrics, opts...)
+	} else {
+		c, err = cache.NewLRUCache(capacity, 500*time.Millisecond, metrics, opts...)
+	}
+	if err != nil {
+		log.Fatalf("Failed to create cache: %v", err)
+	}
 
 	var srv server.Server
 	if useGRPC {
 		// TODO: expose promtheus metrics for gRPC server
-		srv = server.NewGRPCServer(cache, metrics)
+		srv = server.NewGRPCServer(c, metrics)
 		log.Printf("Starting gRPC server on %s:%d", host, port)
 	} else {
-		srv = server.NewHTTPServer(cache, metrics)
+		srv = server.NewHTTPServer(c, metrics)
 		log.Printf("Starting HTTP server on %s:%d", host, port)
 	}
 
@@ -95,7 +133,7 @@ func runServer(cmd *cobra.Command, args []string) {
 
 	<-ch
 	log.Println("Stopping server...")
-	cache.Stop()
+	c.Stop()
 	srv.Stop(context.Background())
 }
 
@@ -114,6 +152,9 @@ func runClient(cmd *cobra.Command, args []string) {
 	fmt.Println("TinyCache CLI (type 'help' for commands, 'exit' to quit)")
 	fmt.Printf("Connected to %s\n", addr)
 
+	// ops are queued between multi and exec
+	var ops []*proto.TxOp
+	inTx := false
 	for {
 		fmt.Print("> ")
 		input, err := reader.ReadString('\n')
@@ -130,6 +171,17 @@ func runClient(cmd *cobra.Command, args []string) {
 		args := strings.Fields(input)
 		cmd := strings.ToLower(args[0])
 
+		if inTx && cmd != "exec" && cmd != "discard" && cmd != "help" {
+			op, err := parseTxOp(cmd, args)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			ops = append(ops, op)
+			fmt.Println("QUEUED")
+			continue
+		}
+
 		switch cmd {
 		case "exit", "quit":
 			return
@@ -140,10 +192,16 @@ func runClient(cmd *cobra.Command, args []string) {
 				fmt.Println("Usage: get <bucket> <key>")
 				continue
 			}
-			handleGet(client, args[1], args[2])
-		case "set":
+			handleGet(client, args[1], args[2], false)
+		case "gets":
+			if len(args) != 3 {
+				fmt.Println("Usage: gets <bucket> <key>")
+				continue
+			}
+			handleGet(client, args[1], args[2], true)
+		case "set", "setnx", "setxx", "getset":
 			if len(args) < 4 {
-				fmt.Println("Usage: set <bucket> <key> <value> [ttl_ms]")
+				fmt.Printf("Usage: %s <bucket> <key> <value> [ttl_ms]\n", cmd)
 				continue
 			}
 			var ttl int64 = 0
@@ -155,7 +213,146 @@ func runClient(cmd *cobra.Command, args []string) {
 					continue
 				}
 			}
-			handleSet(client, args[1], args[2], args[3], ttl)
+			handleSet(client, &proto.SetRequest{
+				Bucket: args[1],
+				Key:    args[2],
+				Value:  []byte(args[3]),
+				TtlMs:  int32(ttl),
+				Nx:     cmd == "setnx",
+				Xx:     cmd == "setxx",
+				Get:    cmd == "getset",
+			})
+		case "cas":
+			if len(args) < 5 {
+				fmt.Println("Usage: cas <bucket> <key> <value> <version> [ttl_ms]")
+				continue
+			}
+			version, err := strconv.ParseUint(args[4], 10, 64)
+			if err != nil {
+				fmt.Printf("Invalid version: %v\n", err)
+				continue
+			}
+			var ttl int64 = 0
+			if len(args) > 5 {
+				ttl, err = strconv.ParseInt(args[5], 10, 64)
+				if err != nil {
+					fmt.Printf("Invalid TTL: %v\n", err)
+					continue
+				}
+			}
+			handleCompareAndSet(client, args[1], args[2], args[3], version, ttl)
+		case "incr", "decr":
+			req, err := parseIncrement(cmd, args)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			handleIncrement(client, req)
+		case "ttl", "persist":
+			if len(args) != 3 {
+				fmt.Printf("Usage: %s <bucket> <key>\n", cmd)
+				continue
+			}
+			if cmd == "ttl" {
+				handleTTL(client, args[1], args[2])
+			} else {
+				handlePersist(client, args[1], args[2])
+			}
+		case "touch":
+			if len(args) != 4 {
+				fmt.Println("Usage: touch <bucket> <key> <ttl_ms>")
+				continue
+			}
+			ttl, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil {
+				fmt.Printf("Invalid TTL: %v\n", err)
+				continue
+			}
+			handleTouch(client, args[1], args[2], ttl)
+		case "absent":
+			if len(args) < 3 {
+				fmt.Println("Usage: absent <bucket> <key> [ttl_ms]")
+				continue
+			}
+			var ttl int64 = 0
+			if len(args) > 3 {
+				var err error
+				ttl, err = strconv.ParseInt(args[3], 10, 64)
+				if err != nil {
+					fmt.Printf("Invalid TTL: %v\n", err)
+					continue
+				}
+			}
+			handleSetAbsent(client, args[1], args[2], ttl)
+		case "mget", "mdel":
+			if len(args) < 3 {
+				fmt.Printf("Usage: %s <bucket> <key> [key...]\n", cmd)
+				continue
+			}
+			if cmd == "mget" {
+				handleMGet(client, args[1], args[2:])
+			} else {
+				handleMDelete(client, args[1], args[2:])
+			}
+		case "mset":
+			if len(args) < 4 || len(args)%2 != 0 {
+				fmt.Println("Usage: mset <bucket> <key> <value> [key value...]")
+				continue
+			}
+			handleMSet(client, args[1], args[2:])
+		case "multi":
+			inTx = true
+			ops = nil
+			fmt.Println("OK")
+		case "exec", "discard":
+			if !inTx {
+				fmt.Printf("%s without multi\n", cmd)
+				continue
+			}
+			if cmd == "exec" {
+				handleTransaction(client, ops)
+			} else {
+				fmt.Println("OK")
+			}
+			inTx = false
+			ops = nil
+		case "buckets":
+			handleListBuckets(client)
+		case "stats":
+			if len(args) != 2 {
+				fmt.Println("Usage: stats <bucket>")
+				continue
+			}
+			handleBucketStats(client, args[1])
+		case "scan":
+			if len(args) < 2 || len(args) > 4 {
+				fmt.Println("Usage: scan <bucket> [match] [count]")
+				continue
+			}
+			match := ""
+			if len(args) > 2 {
+				match = args[2]
+			}
+			var count int64 = 0
+			if len(args) > 3 {
+				var err error
+				count, err = strconv.ParseInt(args[3], 10, 32)
+				if err != nil {
+					fmt.Printf("Invalid count: %v\n", err)
+					continue
+				}
+			}
+			handleScan(client, args[1], match, int32(count))
+		case "flush":
+			if len(args) > 2 {
+				fmt.Println("Usage: flush [bucket]")
+				continue
+			}
+			bucket := ""
+			if len(args) == 2 {
+				bucket = args[1]
+			}
+			handleFlush(client, bucket)
 		case "del", "delete":
 			if len(args) != 3 {
 				fmt.Println("Usage: del <bucket> <key>")
@@ -172,13 +369,40 @@ func runClient(cmd *cobra.Command, args []string) {
 func printHelp() {
 	fmt.Println("Available commands:")
 	fmt.Println("  get <bucket> <key>                    Get value by bucket and key")
+	fmt.Println("  gets <bucket> <key>                   Get value and its version")
 	fmt.Println("  set <bucket> <key> <value> [ttl_ms]  Set value with optional TTL in milliseconds")
+	fmt.Println("  setnx <bucket> <key> <value> [ttl_ms] Set value if key does not exist")
+	fmt.Println("  setxx <bucket> <key> <value> [ttl_ms] Set value if key exists")
+	fmt.Println("  getset <bucket> <key> <value> [ttl_ms]")
+	fmt.Println("                                        Set value and print the old value")
+	fmt.Println("  cas <bucket> <key> <value> <version> [ttl_ms]")
+	fmt.Println("                                        Set value if version matches, 0 means not exists")
+	fmt.Println("  incr <bucket> <key> [delta] [initial] [ttl_ms]")
+	fmt.Println("                                        Increment integer value, missing key starts from initial with TTL")
+	fmt.Println("  decr <bucket> <key> [delta] [initial] [ttl_ms]")
+	fmt.Println("                                        Decrement integer value, missing key starts from initial with TTL")
+	fmt.Println("  ttl <bucket> <key>                    Show remaining TTL in milliseconds, -1 means no TTL")
+	fmt.Println("  touch <bucket> <key> <ttl_ms>         Set new TTL without changing the value")
+	fmt.Println("  persist <bucket> <key>                Remove TTL")
+	fmt.Println("  absent <bucket> <key> [ttl_ms]        Cache key as known absent")
 	fmt.Println("  del <bucket> <key>                    Delete value by bucket and key")
+	fmt.Println("  mget <bucket> <key> [key...]          Get multiple keys in one request")
+	fmt.Println("  mset <bucket> <key> <value> [key value...]")
+	fmt.Println("                                        Set multiple keys in one request")
+	fmt.Println("  mdel <bucket> <key> [key...]          Delete multiple keys in one request")
+	fmt.Println("  multi                                 Queue set, cas, del, incr, decr and check until exec")
+	fmt.Println("  check <bucket> <key> <version>        Abort the transaction if version does not match")
+	fmt.Println("  exec                                  Apply queued commands all or nothing")
+	fmt.Println("  discard                               Drop queued commands")
+	fmt.Println("  buckets                               List non empty buckets")
+	fmt.Println("  stats <bucket>                        Show number of keys, bytes and time range of a bucket")
+	fmt.Println("  scan <bucket> [match] [count]         List keys matching glob pattern e.g. user:*")
+	fmt.Println("  flush [bucket]                        Delete all keys in the bucket or the entire cache")
 	fmt.Println("  help                                  Show this help message")
 	fmt.Println("  exit                                  Exit the client")
 }
 
-func handleGet(client proto.TinyCacheClient, bucket, key string) {
+func handleGet(client proto.TinyCacheClient, bucket, key string, withVersion bool) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
@@ -190,18 +414,123 @@ func handleGet(client proto.TinyCacheClient, bucket, key string) {
 		fmt.Printf("Error: %v\n", err)
 		return
 	}
+	if resp.Absent {
+		fmt.Println("(absent)")
+		return
+	}
+	if withVersion {
+		fmt.Printf("%s (version %d)\n", resp.Value, resp.Version)
+		return
+	}
 	fmt.Printf("%s\n", resp.Value)
 }
 
-func handleSet(client proto.TinyCacheClient, bucket, key, value string, ttlMs int64) {
+func handleSet(client proto.TinyCacheClient, req *proto.SetRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.Set(ctx, req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	switch {
+	case req.Get && resp.Existed:
+		fmt.Printf("%s\n", resp.OldValue)
+	case req.Get:
+		fmt.Println("(nil)")
+	case !resp.Applied:
+		fmt.Println("(not set)")
+	default:
+		fmt.Println("OK")
+	}
+}
+
+func handleCompareAndSet(client proto.TinyCacheClient, bucket, key, value string, version uint64, ttlMs int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.CompareAndSet(ctx, &proto.CompareAndSetRequest{
+		Bucket:  bucket,
+		Key:     key,
+		Value:   []byte(value),
+		TtlMs:   int32(ttlMs),
+		Version: version,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("OK (version %d)\n", resp.Version)
+}
+
+func handleIncrement(client proto.TinyCacheClient, req *proto.IncrementRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.Increment(ctx, req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Println(resp.Value)
+}
+
+func handleTTL(client proto.TinyCacheClient, bucket, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.TTL(ctx, &proto.TTLRequest{
+		Bucket: bucket,
+		Key:    key,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Println(resp.TtlMs)
+}
+
+func handleTouch(client proto.TinyCacheClient, bucket, key string, ttlMs int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.Touch(ctx, &proto.TouchRequest{
+		Bucket: bucket,
+		Key:    key,
+		TtlMs:  int32(ttlMs),
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Println("OK")
+}
+
+func handlePersist(client proto.TinyCacheClient, bucket, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.Persist(ctx, &proto.PersistRequest{
+		Bucket: bucket,
+		Key:    key,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Println("OK")
+}
+
+func handleSetAbsent(client proto.TinyCacheClient, bucket, key string, ttlMs int64) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	_, err := client.Set(ctx, &proto.SetRequest{
 		Bucket: bucket,
 		Key:    key,
-		Value:  []byte(value),
 		TtlMs:  int32(ttlMs),
+		Absent: true,
 	})
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
@@ -210,6 +539,278 @@ func handleSet(client proto.TinyCacheClient, bucket, key, value string, ttlMs in
 	fmt.Println("OK")
 }
 
+func handleMGet(client proto.TinyCacheClient, bucket string, keys []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req := &proto.MGetRequest{}
+	for _, key := range keys {
+		req.Keys = append(req.Keys, &proto.GetRequest{Bucket: bucket, Key: key})
+	}
+	resp, err := client.MGet(ctx, req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	for i, r := range resp.Results {
+		switch {
+		case r.Status.Code != 0:
+			fmt.Printf("%s: Error: %s\n", keys[i], r.Status.Message)
+		case r.Value.Absent:
+			fmt.Printf("%s: (absent)\n", keys[i])
+		default:
+			fmt.Printf("%s: %s\n", keys[i], r.Value.Value)
+		}
+	}
+}
+
+func handleMSet(client proto.TinyCacheClient, bucket string, pairs []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req := &proto.MSetRequest{}
+	var keys []string
+	for i := 0; i < len(pairs); i += 2 {
+		req.Items = append(req.Items, &proto.SetRequest{Bucket: bucket, Key: pairs[i], Value: []byte(pairs[i+1])})
+		keys = append(keys, pairs[i])
+	}
+	resp, err := client.MSet(ctx, req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printKeyStatuses(keys, resp.Statuses)
+}
+
+func handleMDelete(client proto.TinyCacheClient, bucket string, keys []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req := &proto.MDeleteRequest{}
+	for _, key := range keys {
+		req.Keys = append(req.Keys, &proto.DeleteRequest{Bucket: bucket, Key: key})
+	}
+	resp, err := client.MDelete(ctx, req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	printKeyStatuses(keys, resp.Statuses)
+}
+
+// printKeyStatuses prints OK or the error of each key in batch.
+func printKeyStatuses(keys []string, statuses []*proto.KeyStatus) {
+	for i, st := range statuses {
+		if st.Code != 0 {
+			fmt.Printf("%s: Error: %s\n", keys[i], st.Message)
+			continue
+		}
+		fmt.Printf("%s: OK\n", keys[i])
+	}
+}
+
+// parseIncrement parses incr and decr with optional delta, initial and ttl_ms,
+// delta is negated for decr but initial is not.
+func parseIncrement(cmd string, args []string) (*proto.IncrementRequest, error) {
+	if len(args) < 3 || len(args) > 6 {
+		return nil, fmt.Errorf("Usage: %s <bucket> <key> [delta] [initial] [ttl_ms]", cmd)
+	}
+	req := &proto.IncrementRequest{Bucket: args[1], Key: args[2], Delta: 1}
+	if len(args) > 3 {
+		delta, err := strconv.ParseInt(args[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid delta: %v", err)
+		}
+		req.Delta = delta
+	}
+	if cmd == "decr" {
+		req.Delta = -req.Delta
+	}
+	if len(args) > 4 {
+		initial, err := strconv.ParseInt(args[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid initial: %v", err)
+		}
+		req.Initial = initial
+	}
+	if len(args) > 5 {
+		ttl, err := strconv.ParseInt(args[5], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid TTL: %v", err)
+		}
+		req.TtlMs = int32(ttl)
+	}
+	return req, nil
+}
+
+// parseTxOp parses a command queued in multi.
+func parseTxOp(cmd string, args []string) (*proto.TxOp, error) {
+	parseInt := func(i int, name string) (int64, error) {
+		n, err := strconv.ParseInt(args[i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid %s: %v", name, err)
+		}
+		return n, nil
+	}
+	switch cmd {
+	case "set", "cas":
+		n := 4
+		if cmd == "cas" {
+			n = 5
+		}
+		if len(args) != n && len(args) != n+1 {
+			if cmd == "cas" {
+				return nil, fmt.Errorf("Usage: cas <bucket> <key> <value> <version> [ttl_ms]")
+			}
+			return nil, fmt.Errorf("Usage: set <bucket> <key> <value> [ttl_ms]")
+		}
+		op := &proto.TxOp{Type: proto.TxOp_SET, Bucket: args[1], Key: args[2], Value: []byte(args[3])}
+		if cmd == "cas" {
+			version, err := strconv.ParseUint(args[4], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid version: %v", err)
+			}
+			op.CheckVersion = true
+			op.Version = version
+		}
+		if len(args) > n {
+			ttl, err := parseInt(n, "TTL")
+			if err != nil {
+				return nil, err
+			}
+			op.TtlMs = int32(ttl)
+		}
+		return op, nil
+	case "del", "delete":
+		if len(args) != 3 {
+			return nil, fmt.Errorf("Usage: del <bucket> <key>")
+		}
+		return &proto.TxOp{Type: proto.TxOp_DELETE, Bucket: args[1], Key: args[2]}, nil
+	case "incr", "decr":
+		req, err := parseIncrement(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.TxOp{Type: proto.TxOp_INCREMENT, Bucket: req.Bucket, Key: req.Key, Delta: req.Delta,
+			Initial: req.Initial, TtlMs: req.TtlMs}, nil
+	case "check":
+		if len(args) != 4 {
+			return nil, fmt.Errorf("Usage: check <bucket> <key> <version>")
+		}
+		version, err := strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid version: %v", err)
+		}
+		return &proto.TxOp{Type: proto.TxOp_CHECK, Bucket: args[1], Key: args[2], Version: version}, nil
+	default:
+		return nil, fmt.Errorf("Command %s is not allowed in multi, use exec or discard", cmd)
+	}
+}
+
+func handleTransaction(client proto.TinyCacheClient, ops []*proto.TxOp) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.Transaction(ctx, &proto.TransactionRequest{Ops: ops})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	for i, r := range resp.Results {
+		if ops[i].Type == proto.TxOp_INCREMENT {
+			fmt.Printf("%d) %d (version %d)\n", i+1, r.Value, r.Version)
+			continue
+		}
+		fmt.Printf("%d) OK (version %d)\n", i+1, r.Version)
+	}
+}
+
+func handleListBuckets(client proto.TinyCacheClient) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.ListBuckets(ctx, &proto.ListBucketsRequest{})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(resp.Buckets) == 0 {
+		fmt.Println("(empty)")
+		return
+	}
+	for _, bucket := range resp.Buckets {
+		fmt.Println(bucket)
+	}
+}
+
+func handleBucketStats(client proto.TinyCacheClient, bucket string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.GetBucketStats(ctx, &proto.GetBucketStatsRequest{
+		Bucket: bucket,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("entries: %d\n", resp.Entries)
+	fmt.Printf("bytes: %d\n", resp.Bytes)
+	fmt.Printf("oldest: %s\n", time.UnixMilli(resp.OldestMs).Format(time.RFC3339))
+	fmt.Printf("newest: %s\n", time.UnixMilli(resp.NewestMs).Format(time.RFC3339))
+}
+
+// handleScan prints all the keys, count is the page size of the stream.
+func handleScan(client proto.TinyCacheClient, bucket, match string, count int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.Scan(ctx, &proto.ScanRequest{
+		Bucket: bucket,
+		Match:  match,
+		Count:  count,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	n := 0
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		for _, key := range resp.Keys {
+			fmt.Println(key)
+		}
+		n += len(resp.Keys)
+	}
+	fmt.Printf("(%d keys)\n", n)
+}
+
+// handleFlush flushes the entire cache if bucket is empty.
+func handleFlush(client proto.TinyCacheClient, bucket string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var resp *proto.FlushResponse
+	var err error
+	if bucket == "" {
+		resp, err = client.Flush(ctx, &proto.FlushRequest{})
+	} else {
+		resp, err = client.FlushBucket(ctx, &proto.FlushBucketRequest{Bucket: bucket})
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("OK (deleted %d)\n", resp.Deleted)
+}
+
 func handleDelete(client proto.TinyCacheClient, bucket, key string) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -21,9 +21,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TinyCache_Get_FullMethodName    = "/tinycache.TinyCache/Get"
-	TinyCache_Set_FullMethodName    = "/tinycache.TinyCache/Set"
-	TinyCache_Delete_FullMethodName = "/tinycache.TinyCache/Delete"
+	TinyCache_Get_FullMethodName              = "/tinycache.TinyCache/Get"
+	TinyCache_Set_FullMethodName              = "/tinycache.TinyCache/Set"
+	TinyCache_Delete_FullMethodName           = "/tinycache.TinyCache/Delete"
+	TinyCache_CompareAndSet_FullMethodName    = "/tinycache.TinyCache/CompareAndSet"
+	TinyCache_CompareAndDelete_FullMethodName = "/tinycache.TinyCache/CompareAndDelete"
+	TinyCache_Increment_FullMethodName        = "/tinycache.TinyCache/Increment"
+	TinyCache_TTL_FullMethodName              = "/tinycache.TinyCache/TTL"
+	TinyCache_Touch_FullMethodName            = "/tinycache.TinyCache/Touch"
+	TinyCache_Persist_FullMethodName          = "/tinycache.TinyCache/Persist"
+	TinyCache_MGet_FullMethodName             = "/tinycache.TinyCache/MGet"
+	TinyCache_MSet_FullMethodName             = "/tinycache.TinyCache/MSet"
+	TinyCache_MDelete_FullMethodName          = "/tinycache.TinyCache/MDelete"
+	TinyCache_Transaction_FullMethodName      = "/tinycache.TinyCache/Transaction"
+	TinyCache_ConfigureBucket_FullMethodName  = "/tinycache.TinyCache/ConfigureBucket"
+	TinyCache_GetBucketConfig_FullMethodName  = "/tinycache.TinyCache/GetBucketConfig"
+	TinyCache_ListBuckets_FullMethodName      = "/tinycache.TinyCache/ListBuckets"
+	TinyCache_GetBucketStats_FullMethodName   = "/tinycache.TinyCache/GetBucketStats"
+	TinyCache_Scan_FullMethodName             = "/tinycache.TinyCache/Scan"
+	TinyCache_DeleteMatching_FullMethodName   = "/tinycache.TinyCache/DeleteMatching"
+	TinyCache_InvalidateTag_FullMethodName    = "/tinycache.TinyCache/InvalidateTag"
+	TinyCache_FlushBucket_FullMethodName      = "/tinycache.TinyCache/FlushBucket"
+	TinyCache_Flush_FullMethodName            = "/tinycache.TinyCache/Flush"
 )
 
 // TinyCacheClient is the client API for TinyCache service.
@@ -31,8 +50,34 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TinyCacheClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// Returns Aborted if the version does not match
+	CompareAndSet(ctx context.Context, in *CompareAndSetRequest, opts ...grpc.CallOption) (*CompareAndSetResponse, error)
+	CompareAndDelete(ctx context.Context, in *CompareAndDeleteRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// Returns FailedPrecondition if the value is not an integer
+	Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error)
+	TTL(ctx context.Context, in *TTLRequest, opts ...grpc.CallOption) (*TTLResponse, error)
+	Touch(ctx context.Context, in *TouchRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	Persist(ctx context.Context, in *PersistRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// Batch operations take the lock once, failed keys don't fail the entire batch
+	MGet(ctx context.Context, in *MGetRequest, opts ...grpc.CallOption) (*MGetResponse, error)
+	MSet(ctx context.Context, in *MSetRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	MDelete(ctx context.Context, in *MDeleteRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// Returns Aborted if any version does not match and nothing is applied
+	Transaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// Admin
+	ConfigureBucket(ctx context.Context, in *ConfigureBucketRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetBucketConfig(ctx context.Context, in *GetBucketConfigRequest, opts ...grpc.CallOption) (*BucketConfig, error)
+	ListBuckets(ctx context.Context, in *ListBucketsRequest, opts ...grpc.CallOption) (*ListBucketsResponse, error)
+	GetBucketStats(ctx context.Context, in *GetBucketStatsRequest, opts ...grpc.CallOption) (*BucketStats, error)
+	// Stream pages of keys in key order until the end
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScanResponse], error)
+	DeleteMatching(ctx context.Context, in *DeleteMatchingRequest, opts ...grpc.CallOption) (*FlushResponse, error)
+	InvalidateTag(ctx context.Context, in *InvalidateTagRequest, opts ...grpc.CallOption) (*FlushResponse, error)
+	// Bucket config is kept after flush
+	FlushBucket(ctx context.Context, in *FlushBucketRequest, opts ...grpc.CallOption) (*FlushResponse, error)
+	Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error)
 }
 
 type tinyCacheClient struct {
@@ -53,9 +98,9 @@ func (c *tinyCacheClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.
 	return out, nil
 }
 
-func (c *tinyCacheClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *tinyCacheClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(EmptyResponse)
+	out := new(SetResponse)
 	err := c.cc.Invoke(ctx, TinyCache_Set_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -73,13 +118,238 @@ func (c *tinyCacheClient) Delete(ctx context.Context, in *DeleteRequest, opts ..
 	return out, nil
 }
 
+func (c *tinyCacheClient) CompareAndSet(ctx context.Context, in *CompareAndSetRequest, opts ...grpc.CallOption) (*CompareAndSetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareAndSetResponse)
+	err := c.cc.Invoke(ctx, TinyCache_CompareAndSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) CompareAndDelete(ctx context.Context, in *CompareAndDeleteRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, TinyCache_CompareAndDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementResponse)
+	err := c.cc.Invoke(ctx, TinyCache_Increment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) TTL(ctx context.Context, in *TTLRequest, opts ...grpc.CallOption) (*TTLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TTLResponse)
+	err := c.cc.Invoke(ctx, TinyCache_TTL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) Touch(ctx context.Context, in *TouchRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, TinyCache_Touch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) Persist(ctx context.Context, in *PersistRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, TinyCache_Persist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) MGet(ctx context.Context, in *MGetRequest, opts ...grpc.CallOption) (*MGetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MGetResponse)
+	err := c.cc.Invoke(ctx, TinyCache_MGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) MSet(ctx context.Context, in *MSetRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, TinyCache_MSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) MDelete(ctx context.Context, in *MDeleteRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, TinyCache_MDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) Transaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, TinyCache_Transaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) ConfigureBucket(ctx context.Context, in *ConfigureBucketRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, TinyCache_ConfigureBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) GetBucketConfig(ctx context.Context, in *GetBucketConfigRequest, opts ...grpc.CallOption) (*BucketConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BucketConfig)
+	err := c.cc.Invoke(ctx, TinyCache_GetBucketConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) ListBuckets(ctx context.Context, in *ListBucketsRequest, opts ...grpc.CallOption) (*ListBucketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBucketsResponse)
+	err := c.cc.Invoke(ctx, TinyCache_ListBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) GetBucketStats(ctx context.Context, in *GetBucketStatsRequest, opts ...grpc.CallOption) (*BucketStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BucketStats)
+	err := c.cc.Invoke(ctx, TinyCache_GetBucketStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScanResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TinyCache_ServiceDesc.Streams[0], TinyCache_Scan_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ScanRequest, ScanResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TinyCache_ScanClient = grpc.ServerStreamingClient[ScanResponse]
+
+func (c *tinyCacheClient) DeleteMatching(ctx context.Context, in *DeleteMatchingRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlushResponse)
+	err := c.cc.Invoke(ctx, TinyCache_DeleteMatching_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) InvalidateTag(ctx context.Context, in *InvalidateTagRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlushResponse)
+	err := c.cc.Invoke(ctx, TinyCache_InvalidateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) FlushBucket(ctx context.Context, in *FlushBucketRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlushResponse)
+	err := c.cc.Invoke(ctx, TinyCache_FlushBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyCacheClient) Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlushResponse)
+	err := c.cc.Invoke(ctx, TinyCache_Flush_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TinyCacheServer is the server API for TinyCache service.
 // All implementations must embed UnimplementedTinyCacheServer
 // for forward compatibility.
 type TinyCacheServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
-	Set(context.Context, *SetRequest) (*EmptyResponse, error)
+	Set(context.Context, *SetRequest) (*SetResponse, error)
 	Delete(context.Context, *DeleteRequest) (*EmptyResponse, error)
+	// Returns Aborted if the version does not match
+	CompareAndSet(context.Context, *CompareAndSetRequest) (*CompareAndSetResponse, error)
+	CompareAndDelete(context.Context, *CompareAndDeleteRequest) (*EmptyResponse, error)
+	// Returns FailedPrecondition if the value is not an integer
+	Increment(context.Context, *IncrementRequest) (*IncrementResponse, error)
+	TTL(context.Context, *TTLRequest) (*TTLResponse, error)
+	Touch(context.Context, *TouchRequest) (*EmptyResponse, error)
+	Persist(context.Context, *PersistRequest) (*EmptyResponse, error)
+	// Batch operations take the lock once, failed keys don't fail the entire batch
+	MGet(context.Context, *MGetRequest) (*MGetResponse, error)
+	MSet(context.Context, *MSetRequest) (*BatchResponse, error)
+	MDelete(context.Context, *MDeleteRequest) (*BatchResponse, error)
+	// Returns Aborted if any version does not match and nothing is applied
+	Transaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
+	// Admin
+	ConfigureBucket(context.Context, *ConfigureBucketRequest) (*EmptyResponse, error)
+	GetBucketConfig(context.Context, *GetBucketConfigRequest) (*BucketConfig, error)
+	ListBuckets(context.Context, *ListBucketsRequest) (*ListBucketsResponse, error)
+	GetBucketStats(context.Context, *GetBucketStatsRequest) (*BucketStats, error)
+	// Stream pages of keys in key order until the end
+	Scan(*ScanRequest, grpc.ServerStreamingServer[ScanResponse]) error
+	DeleteMatching(context.Context, *DeleteMatchingRequest) (*FlushResponse, error)
+	InvalidateTag(context.Context, *InvalidateTagRequest) (*FlushResponse, error)
+	// Bucket config is kept after flush
+	FlushBucket(context.Context, *FlushBucketRequest) (*FlushResponse, error)
+	Flush(context.Context, *FlushRequest) (*FlushResponse, error)
 	mustEmbedUnimplementedTinyCacheServer()
 }
 
@@ -93,12 +363,69 @@ type UnimplementedTinyCacheServer struct{}
 func (UnimplementedTinyCacheServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-func (UnimplementedTinyCacheServer) Set(context.Context, *SetRequest) (*EmptyResponse, error) {
+func (UnimplementedTinyCacheServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
 }
 func (UnimplementedTinyCacheServer) Delete(context.Context, *DeleteRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedTinyCacheServer) CompareAndSet(context.Context, *CompareAndSetRequest) (*CompareAndSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareAndSet not implemented")
+}
+func (UnimplementedTinyCacheServer) CompareAndDelete(context.Context, *CompareAndDeleteRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareAndDelete not implemented")
+}
+func (UnimplementedTinyCacheServer) Increment(context.Context, *IncrementRequest) (*IncrementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Increment not implemented")
+}
+func (UnimplementedTinyCacheServer) TTL(context.Context, *TTLRequest) (*TTLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TTL not implemented")
+}
+func (UnimplementedTinyCacheServer) Touch(context.Context, *TouchRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Touch not implemented")
+}
+func (UnimplementedTinyCacheServer) Persist(context.Context, *PersistRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Persist not implemented")
+}
+func (UnimplementedTinyCacheServer) MGet(context.Context, *MGetRequest) (*MGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MGet not implemented")
+}
+func (UnimplementedTinyCacheServer) MSet(context.Context, *MSetRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MSet not implemented")
+}
+func (UnimplementedTinyCacheServer) MDelete(context.Context, *MDeleteRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MDelete not implemented")
+}
+func (UnimplementedTinyCacheServer) Transaction(context.Context, *TransactionRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transaction not implemented")
+}
+func (UnimplementedTinyCacheServer) ConfigureBucket(context.Context, *ConfigureBucketRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureBucket not implemented")
+}
+func (UnimplementedTinyCacheServer) GetBucketConfig(context.Context, *GetBucketConfigRequest) (*BucketConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketConfig not implemented")
+}
+func (UnimplementedTinyCacheServer) ListBuckets(context.Context, *ListBucketsRequest) (*ListBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuckets not implemented")
+}
+func (UnimplementedTinyCacheServer) GetBucketStats(context.Context, *GetBucketStatsRequest) (*BucketStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketStats not implemented")
+}
+func (UnimplementedTinyCacheServer) Scan(*ScanRequest, grpc.ServerStreamingServer[ScanResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (UnimplementedTinyCacheServer) DeleteMatching(context.Context, *DeleteMatchingRequest) (*FlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMatching not implemented")
+}
+func (UnimplementedTinyCacheServer) InvalidateTag(context.Context, *InvalidateTagRequest) (*FlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateTag not implemented")
+}
+func (UnimplementedTinyCacheServer) FlushBucket(context.Context, *FlushBucketRequest) (*FlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushBucket not implemented")
+}
+func (UnimplementedTinyCacheServer) Flush(context.Context, *FlushRequest) (*FlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Flush not implemented")
+}
 func (UnimplementedTinyCacheServer) mustEmbedUnimplementedTinyCacheServer() {}
 func (UnimplementedTinyCacheServer) testEmbeddedByValue()                   {}
 
@@ -174,6 +501,341 @@ func _TinyCache_Delete_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyCache_CompareAndSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareAndSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).CompareAndSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_CompareAndSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).CompareAndSet(ctx, req.(*CompareAndSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_CompareAndDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareAndDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).CompareAndDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_CompareAndDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).CompareAndDelete(ctx, req.(*CompareAndDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_Increment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).Increment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_Increment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).Increment(ctx, req.(*IncrementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_TTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TTLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).TTL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_TTL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).TTL(ctx, req.(*TTLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_Touch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).Touch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_Touch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).Touch(ctx, req.(*TouchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_Persist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PersistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).Persist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_Persist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).Persist(ctx, req.(*PersistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_MGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).MGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_MGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).MGet(ctx, req.(*MGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_MSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).MSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_MSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).MSet(ctx, req.(*MSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_MDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).MDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_MDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).MDelete(ctx, req.(*MDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_Transaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).Transaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_Transaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).Transaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_ConfigureBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).ConfigureBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_ConfigureBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).ConfigureBucket(ctx, req.(*ConfigureBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_GetBucketConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).GetBucketConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_GetBucketConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).GetBucketConfig(ctx, req.(*GetBucketConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_ListBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBucketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).ListBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_ListBuckets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).ListBuckets(ctx, req.(*ListBucketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_GetBucketStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).GetBucketStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_GetBucketStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).GetBucketStats(ctx, req.(*GetBucketStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_Scan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TinyCacheServer).Scan(m, &grpc.GenericServerStream[ScanRequest, ScanResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TinyCache_ScanServer = grpc.ServerStreamingServer[ScanResponse]
+
+func _TinyCache_DeleteMatching_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMatchingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).DeleteMatching(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_DeleteMatching_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).DeleteMatching(ctx, req.(*DeleteMatchingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_InvalidateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).InvalidateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_InvalidateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).InvalidateTag(ctx, req.(*InvalidateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_FlushBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).FlushBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_FlushBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).FlushBucket(ctx, req.(*FlushBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyCache_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyCacheServer).Flush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TinyCache_Flush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyCacheServer).Flush(ctx, req.(*FlushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TinyCache_ServiceDesc is the grpc.ServiceDesc for TinyCache service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -193,7 +855,85 @@ var TinyCache_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _TinyCache_Delete_Handler,
 		},
+		{
+			MethodName: "CompareAndSet",
+			Handler:    _TinyCache_CompareAndSet_Handler,
+		},
+		{
+			MethodName: "CompareAndDelete",
+			Handler:    _TinyCache_CompareAndDelete_Handler,
+		},
+		{
+			MethodName: "Increment",
+			Handler:    _TinyCache_Increment_Handler,
+		},
+		{
+			MethodName: "TTL",
+			Handler:    _TinyCache_TTL_Handler,
+		},
+		{
+			MethodName: "Touch",
+			Handler:    _TinyCache_Touch_Handler,
+		},
+		{
+			MethodName: "Persist",
+			Handler:    _TinyCache_Persist_Handler,
+		},
+		{
+			MethodName: "MGet",
+			Handler:    _TinyCache_MGet_Handler,
+		},
+		{
+			MethodName: "MSet",
+			Handler:    _TinyCache_MSet_Handler,
+		},
+		{
+			MethodName: "MDelete",
+			Handler:    _TinyCache_MDelete_Handler,
+		},
+		{
+			MethodName: "Transaction",
+			Handler:    _TinyCache_Transaction_Handler,
+		},
+		{
+			MethodName: "ConfigureBucket",
+			Handler:    _TinyCache_ConfigureBucket_Handler,
+		},
+		{
+			MethodName: "GetBucketConfig",
+			Handler:    _TinyCache_GetBucketConfig_Handler,
+		},
+		{
+			MethodName: "ListBuckets",
+			Handler:    _TinyCache_ListBuckets_Handler,
+		},
+		{
+			MethodName: "GetBucketStats",
+			Handler:    _TinyCache_GetBucketStats_Handler,
+		},
+		{
+			MethodName: "DeleteMatching",
+			Handler:    _TinyCache_DeleteMatching_Handler,
+		},
+		{
+			MethodName: "InvalidateTag",
+			Handler:    _TinyCache_InvalidateTag_Handler,
+		},
+		{
+			MethodName: "FlushBucket",
+			Handler:    _TinyCache_FlushBucket_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _TinyCache_Flush_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Scan",
+			Handler:       _TinyCache_Scan_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/tinycache.proto",
 }
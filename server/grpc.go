@@ -2,11 +2,14 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/at15/tinycache/cache"
 	"github.com/at15/tinycache/proto"
@@ -50,30 +53,341 @@ func (s *grpcServer) Stop(ctx context.Context) error {
 }
 
 func (s *grpcServer) Get(ctx context.Context, req *proto.GetRequest) (*proto.GetResponse, error) {
-	b, err := s.cache.Get(req.Bucket, req.Key, cache.Options{})
+	b, version, err := s.cache.GetWithVersion(req.Bucket, req.Key, cache.Options{})
+	if errors.Is(err, cache.ErrAbsent) {
+		return &proto.GetResponse{Absent: true}, nil
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &proto.GetResponse{Value: b, Version: version}, nil
+}
+
+func (s *grpcServer) Set(ctx context.Context, req *proto.SetRequest) (*proto.SetResponse, error) {
+	opts, err := s.options(req.TtlMs, req.SoftTtlMs, req.Beta, req.Sliding, req.Tags)
 	if err != nil {
 		return nil, err
 	}
+	n := 0
+	for _, b := range []bool{req.Absent, req.Nx, req.Xx, req.Get} {
+		if b {
+			n++
+		}
+	}
+	if n > 1 {
+		return nil, status.Error(codes.InvalidArgument, "only one of absent, nx, xx and get is allowed")
+	}
+
+	resp := &proto.SetResponse{Applied: true}
+	switch {
+	case req.Absent:
+		err = s.cache.SetAbsent(req.Bucket, req.Key, opts)
+	case req.Nx:
+		resp.Applied, err = s.cache.SetNX(req.Bucket, req.Key, req.Value, opts)
+	case req.Xx:
+		resp.Applied, err = s.cache.SetXX(req.Bucket, req.Key, req.Value, opts)
+	case req.Get:
+		resp.OldValue, resp.Existed, err = s.cache.GetSet(req.Bucket, req.Key, req.Value, opts)
+	default:
+		err = s.cache.Set(req.Bucket, req.Key, req.Value, opts)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return resp, nil
+}
+
+// options converts options of SetRequest and TxOp, so Set, MSet and Transaction accept the same options.
+func (s *grpcServer) options(ttlMs int32, softTTLMs int32, beta float64, sliding bool, tags []string) (cache.Options, error) {
+	opts := cache.Options{
+		TTL:     time.Duration(ttlMs) * time.Millisecond,
+		SoftTTL: time.Duration(softTTLMs) * time.Millisecond,
+		Beta:    beta,
+		Sliding: sliding,
+		Tags:    tags,
+	}
+	if opts.Sliding && opts.TTL <= 0 {
+		return opts, status.Error(codes.InvalidArgument, "sliding requires ttl")
+	}
+	if err := checkRefresh(s.cache, opts); err != nil {
+		return opts, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return opts, nil
+}
+
+func (s *grpcServer) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.EmptyResponse, error) {
+	err := s.cache.Delete(req.Bucket, req.Key)
+	if err != nil {
+		return nil, grpcError(err)
+	}
 
-	return &proto.GetResponse{Value: b}, nil
+	return &proto.EmptyResponse{}, nil
 }
 
-func (s *grpcServer) Set(ctx context.Context, req *proto.SetRequest) (*proto.EmptyResponse, error) {
-	err := s.cache.Set(req.Bucket, req.Key, req.Value, cache.Options{
+func (s *grpcServer) CompareAndSet(ctx context.Context, req *proto.CompareAndSetRequest) (*proto.CompareAndSetResponse, error) {
+	version, err := s.cache.CompareAndSet(req.Bucket, req.Key, req.Value, req.Version, cache.Options{
 		TTL: time.Duration(req.TtlMs) * time.Millisecond,
 	})
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
+	}
+
+	return &proto.CompareAndSetResponse{Version: version}, nil
+}
+
+func (s *grpcServer) CompareAndDelete(ctx context.Context, req *proto.CompareAndDeleteRequest) (*proto.EmptyResponse, error) {
+	err := s.cache.CompareAndDelete(req.Bucket, req.Key, req.Version)
+	if err != nil {
+		return nil, grpcError(err)
 	}
 
 	return &proto.EmptyResponse{}, nil
 }
 
-func (s *grpcServer) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.EmptyResponse, error) {
-	err := s.cache.Delete(req.Bucket, req.Key)
+func (s *grpcServer) Increment(ctx context.Context, req *proto.IncrementRequest) (*proto.IncrementResponse, error) {
+	n, err := s.cache.Increment(req.Bucket, req.Key, req.Delta, req.Initial, cache.Options{
+		TTL: time.Duration(req.TtlMs) * time.Millisecond,
+	})
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
+	}
+
+	return &proto.IncrementResponse{Value: n}, nil
+}
+
+func (s *grpcServer) TTL(ctx context.Context, req *proto.TTLRequest) (*proto.TTLResponse, error) {
+	ttl, err := s.cache.TTL(req.Bucket, req.Key)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if ttl == cache.NoTTL {
+		return &proto.TTLResponse{TtlMs: -1}, nil
+	}
+	return &proto.TTLResponse{TtlMs: ttl.Milliseconds()}, nil
+}
+
+func (s *grpcServer) Touch(ctx context.Context, req *proto.TouchRequest) (*proto.EmptyResponse, error) {
+	err := s.cache.Touch(req.Bucket, req.Key, time.Duration(req.TtlMs)*time.Millisecond)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+func (s *grpcServer) Persist(ctx context.Context, req *proto.PersistRequest) (*proto.EmptyResponse, error) {
+	err := s.cache.Persist(req.Bucket, req.Key)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+func (s *grpcServer) MGet(ctx context.Context, req *proto.MGetRequest) (*proto.MGetResponse, error) {
+	keys := make([]cache.BatchKey, len(req.Keys))
+	for i, k := range req.Keys {
+		keys[i] = cache.BatchKey{Bucket: k.Bucket, Key: k.Key}
+	}
+	resp := &proto.MGetResponse{Results: make([]*proto.MGetResult, len(keys))}
+	for i, r := range s.cache.MGet(keys, cache.Options{}) {
+		// Same as Get, absent key is not an error
+		if errors.Is(r.Err, cache.ErrAbsent) {
+			resp.Results[i] = &proto.MGetResult{Value: &proto.GetResponse{Absent: true}, Status: keyStatus(nil)}
+			continue
+		}
+		resp.Results[i] = &proto.MGetResult{Value: &proto.GetResponse{Value: r.Value, Version: r.Version}, Status: keyStatus(r.Err)}
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) MSet(ctx context.Context, req *proto.MSetRequest) (*proto.BatchResponse, error) {
+	resp := &proto.BatchResponse{Statuses: make([]*proto.KeyStatus, len(req.Items))}
+	var items []cache.BatchItem
+	var indexes []int
+	for i, item := range req.Items {
+		if item.Absent || item.Nx || item.Xx || item.Get {
+			resp.Statuses[i] = &proto.KeyStatus{Code: int32(codes.InvalidArgument), Message: "absent, nx, xx and get are not supported in batch"}
+			continue
+		}
+		opts, err := s.options(item.TtlMs, item.SoftTtlMs, item.Beta, item.Sliding, item.Tags)
+		if err != nil {
+			resp.Statuses[i] = keyStatus(err)
+			continue
+		}
+		items = append(items, cache.BatchItem{Bucket: item.Bucket, Key: item.Key, Value: item.Value, Opts: opts})
+		indexes = append(indexes, i)
+	}
+	for j, err := range s.cache.MSet(items) {
+		resp.Statuses[indexes[j]] = keyStatus(err)
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) MDelete(ctx context.Context, req *proto.MDeleteRequest) (*proto.BatchResponse, error) {
+	keys := make([]cache.BatchKey, len(req.Keys))
+	for i, k := range req.Keys {
+		keys[i] = cache.BatchKey{Bucket: k.Bucket, Key: k.Key}
+	}
+	resp := &proto.BatchResponse{Statuses: make([]*proto.KeyStatus, len(keys))}
+	for i, err := range s.cache.MDelete(keys) {
+		resp.Statuses[i] = keyStatus(err)
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) Transaction(ctx context.Context, req *proto.TransactionRequest) (*proto.TransactionResponse, error) {
+	ops := make([]cache.TxOp, len(req.Ops))
+	for i, op := range req.Ops {
+		opts, err := s.options(op.TtlMs, op.SoftTtlMs, op.Beta, op.Sliding, op.Tags)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "op %d: %s", i, status.Convert(err).Message())
+		}
+		ops[i] = cache.TxOp{
+			// Values of TxOp.Type are the same as cache.TxOpType
+			Type:         cache.TxOpType(op.Type),
+			Bucket:       op.Bucket,
+			Key:          op.Key,
+			Value:        op.Value,
+			Delta:        op.Delta,
+			Initial:      op.Initial,
+			Opts:         opts,
+			CheckVersion: op.CheckVersion,
+			Version:      op.Version,
+		}
+	}
+	results, err := s.cache.Transaction(ops)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &proto.TransactionResponse{Results: make([]*proto.TxResult, len(results))}
+	for i, r := range results {
+		resp.Results[i] = &proto.TxResult{Version: r.Version, Value: r.Value}
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) ListBuckets(ctx context.Context, req *proto.ListBucketsRequest) (*proto.ListBucketsResponse, error) {
+	return &proto.ListBucketsResponse{Buckets: s.cache.ListBuckets()}, nil
+}
+
+func (s *grpcServer) GetBucketStats(ctx context.Context, req *proto.GetBucketStatsRequest) (*proto.BucketStats, error) {
+	stats, err := s.cache.BucketStats(req.Bucket)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &proto.BucketStats{
+		Entries:  int64(stats.Entries),
+		Bytes:    stats.Bytes,
+		OldestMs: stats.Oldest.UnixMilli(),
+		NewestMs: stats.Newest.UnixMilli(),
+	}, nil
+}
+
+// Scan streams pages until the end or the client cancels, count is the page size.
+// Cursor of each page can be used to resume the scan in a new call.
+func (s *grpcServer) Scan(req *proto.ScanRequest, stream proto.TinyCache_ScanServer) error {
+	cursor := req.Cursor
+	for {
+		keys, next, err := s.cache.Scan(req.Bucket, cursor, req.Match, int(req.Count))
+		if err != nil {
+			return grpcError(err)
+		}
+		if err := stream.Send(&proto.ScanResponse{Keys: keys, Cursor: next}); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (s *grpcServer) DeleteMatching(ctx context.Context, req *proto.DeleteMatchingRequest) (*proto.FlushResponse, error) {
+	var n int
+	switch {
+	case req.Match != "" && req.Prefix != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of match and prefix is allowed")
+	case req.Prefix != "":
+		n = s.cache.DeleteByPrefix(req.Bucket, req.Prefix)
+	case req.Match != "":
+		n = s.cache.DeleteByPattern(req.Bucket, req.Match)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "one of match and prefix is required, use FlushBucket to delete all the keys")
+	}
+
+	return &proto.FlushResponse{Deleted: int64(n)}, nil
+}
+
+func (s *grpcServer) InvalidateTag(ctx context.Context, req *proto.InvalidateTagRequest) (*proto.FlushResponse, error) {
+	return &proto.FlushResponse{Deleted: int64(s.cache.InvalidateTag(req.Tag))}, nil
+}
+
+func (s *grpcServer) FlushBucket(ctx context.Context, req *proto.FlushBucketRequest) (*proto.FlushResponse, error) {
+	n, err := s.cache.FlushBucket(req.Bucket)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &proto.FlushResponse{Deleted: int64(n)}, nil
+}
+
+func (s *grpcServer) Flush(ctx context.Context, req *proto.FlushRequest) (*proto.FlushResponse, error) {
+	return &proto.FlushResponse{Deleted: int64(s.cache.Flush())}, nil
+}
+
+func (s *grpcServer) ConfigureBucket(ctx context.Context, req *proto.ConfigureBucketRequest) (*proto.EmptyResponse, error) {
+	err := s.cache.ConfigureBucket(req.Bucket, cache.BucketConfig{
+		MaxEntries: int(req.GetConfig().GetMaxEntries()),
+		MaxBytes:   req.GetConfig().GetMaxBytes(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
 	}
 
 	return &proto.EmptyResponse{}, nil
 }
+
+func (s *grpcServer) GetBucketConfig(ctx context.Context, req *proto.GetBucketConfigRequest) (*proto.BucketConfig, error) {
+	cfg := s.cache.BucketConfig(req.Bucket)
+	return &proto.BucketConfig{
+		MaxEntries: int64(cfg.MaxEntries),
+		MaxBytes:   cfg.MaxBytes,
+	}, nil
+}
+
+// grpcError maps errors returned by [cache.Cache] to gRPC status error,
+// status error e.g. from validation is returned as is.
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case cache.IsMiss(err), errors.Is(err, cache.ErrAbsent):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, cache.ErrTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, cache.ErrInvalidConfig), errors.Is(err, cache.ErrInvalidCursor), errors.Is(err, cache.ErrInvalidOp):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, cache.ErrVersionMismatch):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, cache.ErrNotInteger), errors.Is(err, cache.ErrOverflow):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// keyStatus is the status of a single key in batch, nil error is OK.
+func keyStatus(err error) *proto.KeyStatus {
+	if err == nil {
+		return &proto.KeyStatus{}
+	}
+	st := status.Convert(grpcError(err))
+	return &proto.KeyStatus{Code: int32(st.Code()), Message: st.Message()}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/at15/tinycache/cache"
+)
+
+func TestGRPCError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{cache.ErrNotFound, codes.NotFound},
+		{cache.ErrBucketNotFound, codes.NotFound},
+		{cache.ErrExpired, codes.NotFound},
+		{cache.ErrAbsent, codes.NotFound},
+		{cache.ErrTooLarge, codes.ResourceExhausted},
+		{cache.ErrInvalidConfig, codes.InvalidArgument},
+		{cache.ErrInvalidCursor, codes.InvalidArgument},
+		{cache.ErrInvalidOp, codes.InvalidArgument},
+		{cache.ErrVersionMismatch, codes.Aborted},
+		{cache.ErrNotInteger, codes.FailedPrecondition},
+		{cache.ErrOverflow, codes.FailedPrecondition},
+		{errors.New("unknown"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			for _, err := range wrapped(tt.err) {
+				st := status.Convert(grpcError(err))
+				assert.Equal(t, tt.code, st.Code(), err.Error())
+				assert.Equal(t, err.Error(), st.Message())
+			}
+		})
+	}
+
+	// Status error from validation keeps its code
+	err := status.Error(codes.InvalidArgument, "sliding requires ttl")
+	assert.Equal(t, err, grpcError(err))
+	assert.Equal(t, codes.InvalidArgument, codes.Code(keyStatus(err).Code))
+	assert.Equal(t, codes.OK, codes.Code(keyStatus(nil).Code))
+}
@@ -2,14 +2,27 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/at15/tinycache/cache"
 )
 
+// Errors returned by kvHandler in addition to errors from [cache.Cache].
+var (
+	// errBadRequest is returned for invalid headers or query parameters.
+	errBadRequest = errors.New("bad request")
+	// errPreconditionFailed is returned when conditional write e.g. nx is not applied.
+	errPreconditionFailed = errors.New("precondition failed")
+)
+
 type httpServer struct {
 	cache   cache.Cache
 	metrics cache.MetricsExporter
@@ -27,11 +40,48 @@ func NewHTTPServer(cache cache.Cache, metrics cache.MetricsExporter) Server {
 func (s *httpServer) Start(ctx context.Context, addr string, port int) error {
 	mux := http.NewServeMux()
 	// https://go.dev/blog/routing-enhancements
-	mux.HandleFunc("GET /cache/{bucket}/{key}", requireBucketAndKey(s.handleGet))
-	// ?ttl=10s&policy=lru
-	mux.HandleFunc("PUT /cache/{bucket}/{key}", requireBucketAndKey(s.handleSet))
-	mux.HandleFunc("DELETE /cache/{bucket}/{key}", requireBucketAndKey(s.handleDelete))
+	// ETag is the version of the value
+	mux.HandleFunc("GET /cache/{bucket}/{key}", s.requireBucketAndKey(s.handleGet))
+	// ?ttl=10s&soft_ttl=5s&beta=1&sliding=true&tags=a,b or X-Cache-Tags: a, b header, policy is deprecated and rejected when it is not the cache's policy
+	// soft_ttl and beta are rejected if the server is started without --loader-url
+	// If-Match: "version" or If-None-Match: * for compare and set, 412 if the version does not match
+	// ?nx=true only sets missing key and ?xx=true only sets existing key, 412 if not set
+	// ?get=true returns the old value, 201 if the key did not exist
+	mux.HandleFunc("PUT /cache/{bucket}/{key}", s.requireBucketAndKey(s.handleSet))
+	mux.HandleFunc("DELETE /cache/{bucket}/{key}", s.requireBucketAndKey(s.handleDelete))
+	// X-Cache-TTL-Ms is the remaining TTL in milliseconds, -1 if no TTL
+	mux.HandleFunc("HEAD /cache/{bucket}/{key}", s.requireBucketAndKey(s.handleTTL))
+	// ?ttl=10s sets a new TTL without changing the value, no ttl removes the TTL
+	mux.HandleFunc("PATCH /cache/{bucket}/{key}", s.requireBucketAndKey(s.handleTouch))
+	// ?ttl=10s, GET returns 410 Gone until the marker expires
+	mux.HandleFunc("PUT /cache/{bucket}/{key}/absent", s.requireBucketAndKey(s.handleSetAbsent))
+	// ?delta=-1&initial=10&ttl=10s, delta is 1 by default, ttl only applies to new key
+	mux.HandleFunc("POST /cache/{bucket}/{key}/incr", s.requireBucketAndKey(s.handleIncrement))
+	// {"buckets": ["b1", "b2"]}
+	mux.HandleFunc("GET /cache", s.handleListBuckets)
+	// {"entries": 1, "bytes": 6, "oldest": "...", "newest": "..."}
+	// ?cursor=&match=user:*&count=10 scans keys instead, {"keys": ["user:1"], "cursor": "..."}
+	// start with empty cursor and stop when the returned cursor is empty
+	mux.HandleFunc("GET /cache/{bucket}", s.handleBucket)
+	// {"deleted": 1}, bucket config is kept
+	// ?match=user:1:* or ?prefix=user:1: only deletes matching keys, 400 if the value is empty
+	mux.HandleFunc("DELETE /cache/{bucket}", s.handleDeleteBucket)
+	mux.HandleFunc("DELETE /cache", s.handleFlush)
+	// {"deleted": 1}, deletes keys with the tag in all buckets
+	mux.HandleFunc("DELETE /tags/{tag}", s.handleInvalidateTag)
+	// {"op": "get", "items": [{"bucket": "b1", "key": "k1"}]}, op is one of get, set and delete
+	// set item also has "value", "ttl": "10s", "soft_ttl", "beta", "sliding" and "tags", value is base64 encoded so it can be binary
+	// {"results": [{"bucket": "b1", "key": "k1", "value": "djE=", "version": 1, "status": 200}]}
+	// results are in the same order as items, failed key has the http status and error of the single key API
+	mux.HandleFunc("POST /batch", s.handleBatch)
+	// {"ops": [{"op": "set", "bucket": "b1", "key": "k1", "value": "djE=", "version": 1}, {"op": "incr", "bucket": "b1", "key": "n", "delta": 1}]}
+	// op is one of set, delete, incr and check, options of set are the same as batch, version is optional and 0 means not exists
+	// {"results": [{"version": 2}, {"version": 3, "value": 1}]}, all ops are applied or none of them, 412 if any version does not match
+	mux.HandleFunc("POST /transaction", s.handleTransaction)
 	mux.Handle("GET /stats", s.metrics.HTTPHandler())
+	// {"max_entries": 100, "max_bytes": 1024}
+	mux.HandleFunc("GET /admin/buckets/{bucket}", s.handleGetBucketConfig)
+	mux.HandleFunc("PUT /admin/buckets/{bucket}", s.handleConfigureBucket)
 
 	addr = fmt.Sprintf("%s:%d", addr, port)
 	server := &http.Server{
@@ -51,9 +101,20 @@ func (s *httpServer) Stop(ctx context.Context) error {
 	return s.server.Shutdown(ctx)
 }
 
-type kvHandler func(bucket, key string, body []byte, opts cache.Options) ([]byte, error)
+// kvRequest is a request of a single key, the embedded request is used
+// for headers e.g. If-Match.
+type kvRequest struct {
+	*http.Request
+	bucket string
+	key    string
+	body   []byte
+	opts   cache.Options
+}
 
-func requireBucketAndKey(handler kvHandler) http.HandlerFunc {
+// kvHandler returns the response body, w is only used for response headers.
+type kvHandler func(w http.ResponseWriter, req kvRequest) ([]byte, error)
+
+func (s *httpServer) requireBucketAndKey(handler kvHandler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bucket := r.PathValue("bucket")
 		key := r.PathValue("key")
@@ -61,22 +122,26 @@ func requireBucketAndKey(handler kvHandler) http.HandlerFunc {
 			http.Error(w, "Invalid bucket or key", http.StatusBadRequest)
 			return
 		}
-		// TODO: read operations from request parameters for ttl and policy
+		if err := s.checkPolicy(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
 			http.Error(w, "Failed to read request body", http.StatusBadRequest)
 			return
 		}
 		opts, err := cache.ParseFromRequest(r)
+		if err == nil {
+			err = checkRefresh(s.cache, opts)
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		b, err := handler(bucket, key, body, opts)
+		b, err := handler(w, kvRequest{Request: r, bucket: bucket, key: key, body: body, opts: opts})
 		if err != nil {
-			// TODO: check error type, though we only return error when not found
-			// so it's always 404...
-			http.Error(w, err.Error(), http.StatusNotFound)
+			http.Error(w, err.Error(), httpStatus(err))
 			return
 		}
 
@@ -84,14 +149,512 @@ func requireBucketAndKey(handler kvHandler) http.HandlerFunc {
 	}
 }
 
-func (s *httpServer) handleGet(bucket, key string, body []byte, opts cache.Options) ([]byte, error) {
-	return s.cache.Get(bucket, key, opts)
+// checkPolicy rejects the deprecated policy query parameter if it is different
+// from the policy of the cache. Eviction policy is configured for entire cache
+// when starting the server.
+func (s *httpServer) checkPolicy(r *http.Request) error {
+	policy := r.URL.Query().Get("policy")
+	if policy == "" {
+		return nil
+	}
+	p, err := cache.ParseEvictionPolicy(policy)
+	if err != nil {
+		return err
+	}
+	if p != s.cache.EvictionPolicy() {
+		return fmt.Errorf("policy %s does not match cache policy %s", p, s.cache.EvictionPolicy())
+	}
+	return nil
+}
+
+func (s *httpServer) handleGet(w http.ResponseWriter, req kvRequest) ([]byte, error) {
+	value, version, err := s.cache.GetWithVersion(req.bucket, req.key, req.opts)
+	if err != nil {
+		return nil, err
+	}
+	w.Header().Set("ETag", formatETag(version))
+	return value, nil
+}
+
+// handleSet uses compare and set if there is If-Match or If-None-Match: *.
+// Query flag nx only sets missing key, xx only sets existing key and get returns the old value,
+// they can't be combined with each other or with the headers.
+func (s *httpServer) handleSet(w http.ResponseWriter, req kvRequest) ([]byte, error) {
+	version, cas, err := parsePrecondition(req.Request)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	nx, err := parseBool(q, "nx")
+	if err != nil {
+		return nil, err
+	}
+	xx, err := parseBool(q, "xx")
+	if err != nil {
+		return nil, err
+	}
+	get, err := parseBool(q, "get")
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, b := range []bool{cas, nx, xx, get} {
+		if b {
+			n++
+		}
+	}
+	if n > 1 {
+		return nil, fmt.Errorf("only one of nx, xx, get and precondition headers is allowed: %w", errBadRequest)
+	}
+
+	switch {
+	case cas:
+		version, err = s.cache.CompareAndSet(req.bucket, req.key, req.body, version, req.opts)
+		if err != nil {
+			return nil, err
+		}
+		w.Header().Set("ETag", formatETag(version))
+		return nil, nil
+	case nx, xx:
+		set := s.cache.SetNX
+		if xx {
+			set = s.cache.SetXX
+		}
+		ok, err := set(req.bucket, req.key, req.body, req.opts)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("bucket %s key %s: not set: %w", req.bucket, req.key, errPreconditionFailed)
+		}
+		return nil, nil
+	case get:
+		old, existed, err := s.cache.GetSet(req.bucket, req.key, req.body, req.opts)
+		if err != nil {
+			return nil, err
+		}
+		if !existed {
+			w.WriteHeader(http.StatusCreated)
+		}
+		return old, nil
+	default:
+		return nil, s.cache.Set(req.bucket, req.key, req.body, req.opts)
+	}
+}
+
+func (s *httpServer) handleTTL(w http.ResponseWriter, req kvRequest) ([]byte, error) {
+	ttl, err := s.cache.TTL(req.bucket, req.key)
+	if err != nil {
+		return nil, err
+	}
+	ms := int64(-1)
+	if ttl != cache.NoTTL {
+		ms = ttl.Milliseconds()
+	}
+	w.Header().Set("X-Cache-TTL-Ms", strconv.FormatInt(ms, 10))
+	return nil, nil
 }
 
-func (s *httpServer) handleSet(bucket, key string, body []byte, opts cache.Options) ([]byte, error) {
-	return nil, s.cache.Set(bucket, key, body, opts)
+func (s *httpServer) handleTouch(w http.ResponseWriter, req kvRequest) ([]byte, error) {
+	return nil, s.cache.Touch(req.bucket, req.key, req.opts.TTL)
+}
+
+func (s *httpServer) handleSetAbsent(w http.ResponseWriter, req kvRequest) ([]byte, error) {
+	return nil, s.cache.SetAbsent(req.bucket, req.key, req.opts)
+}
+
+func (s *httpServer) handleIncrement(w http.ResponseWriter, req kvRequest) ([]byte, error) {
+	q := req.URL.Query()
+	delta, err := parseInt(q, "delta", 1)
+	if err != nil {
+		return nil, err
+	}
+	initial, err := parseInt(q, "initial", 0)
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.cache.Increment(req.bucket, req.key, delta, initial, req.opts)
+	if err != nil {
+		return nil, err
+	}
+	return strconv.AppendInt(nil, n, 10), nil
+}
+
+// handleDelete uses compare and delete if there is If-Match.
+func (s *httpServer) handleDelete(w http.ResponseWriter, req kvRequest) ([]byte, error) {
+	version, ok, err := parsePrecondition(req.Request)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, s.cache.Delete(req.bucket, req.key)
+	}
+	return nil, s.cache.CompareAndDelete(req.bucket, req.key, version)
+}
+
+// parseInt returns def if the query parameter is empty.
+func parseInt(q url.Values, name string, def int64) (int64, error) {
+	s := q.Get(name)
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s: %w", name, s, errBadRequest)
+	}
+	return n, nil
+}
+
+func parseBool(q url.Values, name string) (bool, error) {
+	s := q.Get(name)
+	if s == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %s: %w", name, s, errBadRequest)
+	}
+	return b, nil
+}
+
+func formatETag(version uint64) string {
+	return strconv.Quote(strconv.FormatUint(version, 10))
+}
+
+// parsePrecondition returns the version in If-Match, or 0 for If-None-Match: *
+// i.e. the key must not exist. It returns false if there is no precondition.
+func parsePrecondition(r *http.Request) (uint64, bool, error) {
+	if r.Header.Get("If-None-Match") == "*" {
+		return 0, true, nil
+	}
+	etag := r.Header.Get("If-Match")
+	if etag == "" {
+		return 0, false, nil
+	}
+	version, err := strconv.ParseUint(strings.Trim(etag, `"`), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid If-Match %s: %w", etag, errBadRequest)
+	}
+	return version, true, nil
+}
+
+type listBucketsResponse struct {
+	Buckets []string `json:"buckets"`
+}
+
+type scanResponse struct {
+	Keys   []string `json:"keys"`
+	Cursor string   `json:"cursor"`
+}
+
+type flushResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+type batchRequest struct {
+	Op    string      `json:"op"`
+	Items []batchItem `json:"items"`
+}
+
+type batchItem struct {
+	Bucket  string   `json:"bucket"`
+	Key     string   `json:"key"`
+	Value   []byte   `json:"value"`
+	TTL     string   `json:"ttl"`
+	SoftTTL string   `json:"soft_ttl"`
+	Beta    float64  `json:"beta"`
+	Sliding bool     `json:"sliding"`
+	Tags    []string `json:"tags"`
+}
+
+// options uses the same parser as query parameters of a single key.
+func (item batchItem) options(c cache.Cache) (cache.Options, error) {
+	q := url.Values{}
+	q.Set("ttl", item.TTL)
+	q.Set("soft_ttl", item.SoftTTL)
+	q.Set("beta", strconv.FormatFloat(item.Beta, 'g', -1, 64))
+	q.Set("sliding", strconv.FormatBool(item.Sliding))
+	q["tags"] = item.Tags
+	opts, err := cache.ParseFromQuery(q)
+	if err == nil {
+		err = checkRefresh(c, opts)
+	}
+	if err != nil {
+		return opts, fmt.Errorf("%s: %w", err.Error(), errBadRequest)
+	}
+	return opts, nil
 }
 
-func (s *httpServer) handleDelete(bucket, key string, body []byte, _ cache.Options) ([]byte, error) {
-	return nil, s.cache.Delete(bucket, key)
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+type batchResult struct {
+	Bucket  string `json:"bucket"`
+	Key     string `json:"key"`
+	Value   []byte `json:"value,omitempty"`
+	Version uint64 `json:"version,omitempty"`
+	Status  int    `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (r *batchResult) setError(err error) {
+	r.Status = http.StatusOK
+	if err != nil {
+		r.Status = httpStatus(err)
+		r.Error = err.Error()
+	}
+}
+
+// handleBatch calls MGet, MSet or MDelete so keys are handled under the lock once.
+func (s *httpServer) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid batch request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	results := make([]batchResult, len(req.Items))
+	keys := make([]cache.BatchKey, len(req.Items))
+	for i, item := range req.Items {
+		if item.Bucket == "" || item.Key == "" {
+			http.Error(w, fmt.Sprintf("Invalid bucket or key of item %d", i), http.StatusBadRequest)
+			return
+		}
+		results[i] = batchResult{Bucket: item.Bucket, Key: item.Key}
+		keys[i] = cache.BatchKey{Bucket: item.Bucket, Key: item.Key}
+	}
+
+	switch req.Op {
+	case "get":
+		for i, res := range s.cache.MGet(keys, cache.Options{}) {
+			results[i].Value = res.Value
+			results[i].Version = res.Version
+			results[i].setError(res.Err)
+		}
+	case "set":
+		// Items with invalid options are skipped and reported in results.
+		var items []cache.BatchItem
+		var indexes []int
+		for i, item := range req.Items {
+			opts, err := item.options(s.cache)
+			if err != nil {
+				results[i].setError(err)
+				continue
+			}
+			items = append(items, cache.BatchItem{Bucket: item.Bucket, Key: item.Key, Value: item.Value, Opts: opts})
+			indexes = append(indexes, i)
+		}
+		for j, err := range s.cache.MSet(items) {
+			results[indexes[j]].setError(err)
+		}
+	case "delete":
+		for i, err := range s.cache.MDelete(keys) {
+			results[i].setError(err)
+		}
+	default:
+		http.Error(w, fmt.Sprintf("Invalid batch op %q, must be one of get, set and delete", req.Op), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, batchResponse{Results: results})
+}
+
+type transactionRequest struct {
+	Ops []txOp `json:"ops"`
+}
+
+type txOp struct {
+	batchItem
+	Op      string  `json:"op"`
+	Delta   int64   `json:"delta"`
+	Initial int64   `json:"initial"`
+	Version *uint64 `json:"version"`
+}
+
+var txOpTypes = map[string]cache.TxOpType{
+	"set":    cache.TxSet,
+	"delete": cache.TxDelete,
+	"incr":   cache.TxIncrement,
+	"check":  cache.TxCheck,
+}
+
+type transactionResponse struct {
+	Results []txResult `json:"results"`
+}
+
+type txResult struct {
+	Version uint64 `json:"version"`
+	Value   int64  `json:"value,omitempty"`
+}
+
+func (s *httpServer) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	var req transactionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid transaction: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	ops := make([]cache.TxOp, len(req.Ops))
+	for i, op := range req.Ops {
+		typ, ok := txOpTypes[op.Op]
+		if !ok {
+			http.Error(w, fmt.Sprintf("Invalid op %q of op %d, must be one of set, delete, incr and check", op.Op, i), http.StatusBadRequest)
+			return
+		}
+		if op.Bucket == "" || op.Key == "" {
+			http.Error(w, fmt.Sprintf("Invalid bucket or key of op %d", i), http.StatusBadRequest)
+			return
+		}
+		opts, err := op.options(s.cache)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("op %d: %v", i, err), httpStatus(err))
+			return
+		}
+		ops[i] = cache.TxOp{
+			Type:         typ,
+			Bucket:       op.Bucket,
+			Key:          op.Key,
+			Value:        op.Value,
+			Delta:        op.Delta,
+			Initial:      op.Initial,
+			Opts:         opts,
+			CheckVersion: op.Version != nil,
+		}
+		if op.Version != nil {
+			ops[i].Version = *op.Version
+		}
+	}
+
+	results, err := s.cache.Transaction(ops)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	resp := transactionResponse{Results: make([]txResult, len(results))}
+	for i, r := range results {
+		resp.Results[i] = txResult{Version: r.Version, Value: r.Value}
+	}
+	writeJSON(w, resp)
+}
+
+func (s *httpServer) handleListBuckets(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, listBucketsResponse{Buckets: s.cache.ListBuckets()})
+}
+
+// handleBucket scans keys if there is any scan parameter, otherwise returns bucket stats.
+func (s *httpServer) handleBucket(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if !q.Has("cursor") && !q.Has("match") && !q.Has("count") {
+		s.handleBucketStats(w, r)
+		return
+	}
+	count, err := parseInt(q, "count", 0)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	keys, cursor, err := s.cache.Scan(r.PathValue("bucket"), q.Get("cursor"), q.Get("match"), int(count))
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	if keys == nil {
+		keys = []string{}
+	}
+	writeJSON(w, scanResponse{Keys: keys, Cursor: cursor})
+}
+
+func (s *httpServer) handleBucketStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.cache.BucketStats(r.PathValue("bucket"))
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	writeJSON(w, stats)
+}
+
+// handleDeleteBucket deletes matching keys if there is match or prefix, otherwise flushes the bucket.
+func (s *httpServer) handleDeleteBucket(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	bucket := r.PathValue("bucket")
+	switch {
+	case q.Has("match") && q.Has("prefix"):
+		http.Error(w, "only one of match and prefix is allowed", http.StatusBadRequest)
+	case q.Get("match") != "":
+		writeJSON(w, flushResponse{Deleted: s.cache.DeleteByPattern(bucket, q.Get("match"))})
+	case q.Get("prefix") != "":
+		writeJSON(w, flushResponse{Deleted: s.cache.DeleteByPrefix(bucket, q.Get("prefix"))})
+	case q.Has("match") || q.Has("prefix"):
+		// Empty value is likely a mistake of the client, flush requires no parameter.
+		http.Error(w, "match or prefix cannot be empty, delete without them to flush the bucket", http.StatusBadRequest)
+	default:
+		s.handleFlushBucket(w, r)
+	}
+}
+
+func (s *httpServer) handleFlushBucket(w http.ResponseWriter, r *http.Request) {
+	n, err := s.cache.FlushBucket(r.PathValue("bucket"))
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	writeJSON(w, flushResponse{Deleted: n})
+}
+
+func (s *httpServer) handleFlush(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, flushResponse{Deleted: s.cache.Flush()})
+}
+
+func (s *httpServer) handleInvalidateTag(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, flushResponse{Deleted: s.cache.InvalidateTag(r.PathValue("tag"))})
+}
+
+func (s *httpServer) handleGetBucketConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.cache.BucketConfig(r.PathValue("bucket")))
+}
+
+func (s *httpServer) handleConfigureBucket(w http.ResponseWriter, r *http.Request) {
+	var cfg cache.BucketConfig
+	if err := decodeJSON(r, &cfg); err != nil {
+		http.Error(w, "Invalid bucket config: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.cache.ConfigureBucket(r.PathValue("bucket"), cfg); err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	writeJSON(w, cfg)
+}
+
+// decodeJSON rejects unknown fields, so unsupported options are not ignored silently.
+func decodeJSON(r *http.Request, v any) error {
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write json response: %v", err)
+	}
+}
+
+// httpStatus maps errors returned by [cache.Cache] to http status code.
+func httpStatus(err error) int {
+	switch {
+	case cache.IsMiss(err):
+		return http.StatusNotFound
+	case errors.Is(err, cache.ErrAbsent):
+		return http.StatusGone
+	case errors.Is(err, cache.ErrVersionMismatch), errors.Is(err, errPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, cache.ErrNotInteger), errors.Is(err, cache.ErrOverflow):
+		return http.StatusConflict
+	case errors.Is(err, cache.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, cache.ErrInvalidConfig), errors.Is(err, cache.ErrInvalidCursor), errors.Is(err, cache.ErrInvalidOp),
+		errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
 }
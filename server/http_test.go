@@ -0,0 +1,48 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/at15/tinycache/cache"
+)
+
+// wrapped returns err as is, wrapped in KeyError like most cache errors,
+// and wrapped again like errors of batch and transaction.
+func wrapped(err error) []error {
+	keyErr := &cache.KeyError{Bucket: "b1", Key: "k1", Err: err}
+	return []error{err, keyErr, fmt.Errorf("transaction op 1: %w", keyErr)}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{cache.ErrNotFound, http.StatusNotFound},
+		{cache.ErrBucketNotFound, http.StatusNotFound},
+		{cache.ErrExpired, http.StatusNotFound},
+		{cache.ErrAbsent, http.StatusGone},
+		{cache.ErrVersionMismatch, http.StatusPreconditionFailed},
+		{errPreconditionFailed, http.StatusPreconditionFailed},
+		{cache.ErrNotInteger, http.StatusConflict},
+		{cache.ErrOverflow, http.StatusConflict},
+		{cache.ErrTooLarge, http.StatusRequestEntityTooLarge},
+		{cache.ErrInvalidConfig, http.StatusBadRequest},
+		{cache.ErrInvalidCursor, http.StatusBadRequest},
+		{cache.ErrInvalidOp, http.StatusBadRequest},
+		{errBadRequest, http.StatusBadRequest},
+		{errors.New("unknown"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			for _, err := range wrapped(tt.err) {
+				assert.Equal(t, tt.status, httpStatus(err), err.Error())
+			}
+		})
+	}
+}
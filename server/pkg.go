@@ -3,9 +3,20 @@ package server
 
 import (
 	"context"
+	"errors"
+
+	"github.com/at15/tinycache/cache"
 )
 
 type Server interface {
 	Start(ctx context.Context, addr string, port int) error
 	Stop(ctx context.Context) error
 }
+
+// checkRefresh rejects soft TTL and beta if the cache has no loader, otherwise they are ignored silently.
+func checkRefresh(c cache.Cache, opts cache.Options) error {
+	if (opts.SoftTTL > 0 || opts.Beta > 0) && !c.HasLoader() {
+		return errors.New("soft_ttl and beta require the server to be started with --loader-url")
+	}
+	return nil
+}